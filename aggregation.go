@@ -1,6 +1,8 @@
 package elastic
 
-import ()
+import (
+	"errors"
+)
 
 const (
 	// Aggs abreviateed constant name for the Aggregation query.
@@ -114,6 +116,22 @@ func (agg *Aggregation) Get() {
 	agg.client.Execute("GET", url, query, agg.parser)
 }
 
+// Do submits this request and returns the result, its aggregations are navigated with Aggs
+// GET /:index/:type/_search
+func (agg *Aggregation) Do() (*AggregationResult, error) {
+	result, err := agg.client.Execute("GET", agg.urlString(), agg.String(), agg.parser)
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case AggregationResult:
+		return &res, nil
+	case Failure:
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
+}
+
 // SetMetric sets the search type with the given value (e.g. count)
 func (agg *Aggregation) SetMetric(name string) *Aggregation {
 	agg.params[SearchType] = name
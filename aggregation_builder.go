@@ -0,0 +1,592 @@
+package elastic
+
+import (
+	"encoding/json"
+)
+
+// Constant names of aggregations and of their parameters, completing the ones of aggregation.go
+const (
+	// DateRange constant name of the 'date_range' bucket, a 'range' bucket on dates accepting date math (e.g. now-10M/M)
+	DateRange = "date_range"
+	// Stats constant name of the 'stats' metric (i.e. count, min, max, avg and sum).
+	Stats = "stats"
+	// ValueCount constant name of the 'value_count' metric, the number of values of a field.
+	ValueCount = "value_count"
+	// TopHits constant name of the 'top_hits' metric, the most relevant documents of each bucket.
+	TopHits = "top_hits"
+	// CalendarInterval a parameter of date histograms, a calendar aware interval (e.g. month, quarter, 1d)
+	CalendarInterval = "calendar_interval"
+	// FixedInterval a parameter of date histograms, an interval of a fixed length (e.g. 90m, 30d)
+	FixedInterval = "fixed_interval"
+	// ExtendedBounds a parameter of histograms forcing the buckets between its min and max to be returned, even empty ones
+	ExtendedBounds = "extended_bounds"
+	// Keyed a parameter of bucket aggregations returning the buckets as an object by key instead of an array
+	Keyed = "keyed"
+	// Ranges a parameter of range aggregations listing the ranges of the buckets
+	Ranges = "ranges"
+	// OtherBucketKey a parameter of 'filters' bucket, the key of the bucket of the documents matching none of the filters
+	OtherBucketKey = "other_bucket_key"
+	// ShardSize a parameter of 'terms' bucket, the number of terms each shard returns (to improve the accuracy of the counts)
+	ShardSize = "shard_size"
+)
+
+// Agg defines the interface of a typed aggregation, see TermsAgg or AvgAgg.
+// The name is the one of the aggregation in the request and in the results (e.g. res.Aggs().Terms(name)).
+type Agg interface {
+	Name() string
+	Dict() Dict
+}
+
+// aggDefinition the common part of the typed aggregations: e.g. {"terms":{"field":"color"},"aggs":{"avg_price":{"avg":{"field":"price"}}}}
+type aggDefinition struct {
+	name string
+	kind string
+	kv   Dict
+	// query the query of a 'filter' bucket, it's the body of the aggregation instead of kv
+	query Query
+	aggs  Dict
+}
+
+// newAggDefinition creates the definition of an aggregation of the given kind
+func newAggDefinition(name, kind string) *aggDefinition {
+	return &aggDefinition{name: name, kind: kind, kv: make(Dict)}
+}
+
+// Name returns the name of this aggregation
+func (agg *aggDefinition) Name() string {
+	return agg.name
+}
+
+// Dict returns the body of this aggregation as a dictionary
+func (agg *aggDefinition) Dict() Dict {
+	dict := Dict{agg.kind: agg.kv}
+	if agg.query != nil {
+		// rendered with the aggregation, so that later changes to the query are kept
+		dict[agg.kind] = clause{query: agg.query, named: true}
+	}
+	if len(agg.aggs) > 0 {
+		dict[Aggs] = agg.aggs
+	}
+	return dict
+}
+
+// String returns a string representation of this aggregation
+func (agg *aggDefinition) String() string {
+	return String(Dict{agg.name: agg.Dict()})
+}
+
+// aggClause a typed aggregation nested in a request body, it is kept as is so that
+// the sub aggregations added to it later are rendered as well
+type aggClause struct {
+	agg Agg
+}
+
+// MarshalJSON renders the nested aggregation
+func (c aggClause) MarshalJSON() ([]byte, error) {
+	return json.Marshal(c.agg.Dict())
+}
+
+// addAggs adds sub aggregations to this aggregation
+func (agg *aggDefinition) addAggs(aggs []Agg) {
+	if agg.aggs == nil {
+		agg.aggs = make(Dict)
+	}
+	for _, sub := range aggs {
+		agg.aggs[sub.Name()] = aggClause{sub}
+	}
+}
+
+// addAggs adds typed aggregations to the 'aggs' of the given request body
+func addAggs(query Dict, aggs []Agg) {
+	dict, ok := query[Aggs].(Dict)
+	if !ok {
+		dict = make(Dict)
+		query[Aggs] = dict
+	}
+	for _, agg := range aggs {
+		dict[agg.Name()] = aggClause{agg}
+	}
+}
+
+// AddAgg adds typed aggregations to this aggregation request
+func (agg *Aggregation) AddAgg(aggs ...Agg) *Aggregation {
+	addAggs(agg.query, aggs)
+	return agg
+}
+
+// AddAgg adds typed aggregations to this search request, their results are in SearchResult.Aggs
+func (search *Search) AddAgg(aggs ...Agg) *Search {
+	addAggs(search.query, aggs)
+	return search
+}
+
+// AddAgg adds typed aggregations nested in this bucket
+func (bucket *Bucket) AddAgg(aggs ...Agg) *Bucket {
+	addAggs(bucket.query, aggs)
+	return bucket
+}
+
+// TermsAggregation a structure representing the 'terms' bucket, a bucket per unique value of a field
+type TermsAggregation struct {
+	*aggDefinition
+}
+
+// TermsAgg creates a new 'terms' bucket on the given field
+func TermsAgg(name, field string) *TermsAggregation {
+	agg := &TermsAggregation{newAggDefinition(name, Terms)}
+	agg.kv[Field] = field
+	return agg
+}
+
+// Size sets the number of buckets to return (10 by default)
+func (agg *TermsAggregation) Size(size int) *TermsAggregation {
+	agg.kv[Size] = size
+	return agg
+}
+
+// ShardSize sets the number of terms each shard returns
+func (agg *TermsAggregation) ShardSize(size int) *TermsAggregation {
+	agg.kv[ShardSize] = size
+	return agg
+}
+
+// MinDocCount sets the minimum number of documents of the returned buckets
+func (agg *TermsAggregation) MinDocCount(count int) *TermsAggregation {
+	agg.kv[MinDocCount] = count
+	return agg
+}
+
+// Order sets the ordering of the buckets, key is _count, _key or the name of a sub metric (e.g. avg_price) and direction is asc or desc
+func (agg *TermsAggregation) Order(key, direction string) *TermsAggregation {
+	agg.kv[Order] = Dict{key: direction}
+	return agg
+}
+
+// Missing sets the value of the documents without the field, by default they are ignored
+func (agg *TermsAggregation) Missing(value interface{}) *TermsAggregation {
+	agg.kv[Missing] = value
+	return agg
+}
+
+// Include filters the terms to return with a regular expression (e.g. "water_.*") or a list of values
+func (agg *TermsAggregation) Include(include interface{}) *TermsAggregation {
+	agg.kv["include"] = include
+	return agg
+}
+
+// Exclude filters out terms with a regular expression or a list of values
+func (agg *TermsAggregation) Exclude(exclude interface{}) *TermsAggregation {
+	agg.kv["exclude"] = exclude
+	return agg
+}
+
+// AddAgg adds sub aggregations computed on each bucket
+func (agg *TermsAggregation) AddAgg(aggs ...Agg) *TermsAggregation {
+	agg.addAggs(aggs)
+	return agg
+}
+
+// HistogramAggregation a structure representing the 'histogram' bucket, a bucket per interval of a numeric field
+type HistogramAggregation struct {
+	*aggDefinition
+}
+
+// HistogramAgg creates a new 'histogram' bucket on the given field, with buckets of the given interval (e.g. 20000)
+func HistogramAgg(name, field string, interval float64) *HistogramAggregation {
+	agg := &HistogramAggregation{newAggDefinition(name, Histogram)}
+	agg.kv[Field] = field
+	agg.kv[Interval] = interval
+	return agg
+}
+
+// MinDocCount sets the minimum number of documents of the returned buckets, 0 returns the empty buckets
+func (agg *HistogramAggregation) MinDocCount(count int) *HistogramAggregation {
+	agg.kv[MinDocCount] = count
+	return agg
+}
+
+// ExtendedBounds returns the buckets between min and max even when they are empty (with MinDocCount(0))
+func (agg *HistogramAggregation) ExtendedBounds(min, max float64) *HistogramAggregation {
+	agg.kv[ExtendedBounds] = Dict{"min": min, "max": max}
+	return agg
+}
+
+// Offset shifts the bucket boundaries by the given value
+func (agg *HistogramAggregation) Offset(offset float64) *HistogramAggregation {
+	agg.kv[Offset] = offset
+	return agg
+}
+
+// Order sets the ordering of the buckets, key is _count, _key or the name of a sub metric and direction is asc or desc
+func (agg *HistogramAggregation) Order(key, direction string) *HistogramAggregation {
+	agg.kv[Order] = Dict{key: direction}
+	return agg
+}
+
+// Keyed returns the buckets as an object by key
+func (agg *HistogramAggregation) Keyed(keyed bool) *HistogramAggregation {
+	agg.kv[Keyed] = keyed
+	return agg
+}
+
+// AddAgg adds sub aggregations computed on each bucket
+func (agg *HistogramAggregation) AddAgg(aggs ...Agg) *HistogramAggregation {
+	agg.addAggs(aggs)
+	return agg
+}
+
+// DateHistogramAggregation a structure representing the 'date_histogram' bucket, a bucket per interval of a date field
+type DateHistogramAggregation struct {
+	*aggDefinition
+}
+
+// DateHistogramAgg creates a new 'date_histogram' bucket on the given field, its interval is set with CalendarInterval or FixedInterval
+func DateHistogramAgg(name, field string) *DateHistogramAggregation {
+	agg := &DateHistogramAggregation{newAggDefinition(name, DateHistogram)}
+	agg.kv[Field] = field
+	return agg
+}
+
+// CalendarInterval sets a calendar aware interval (e.g. month, quarter, 1d), months and years have variable lengths
+func (agg *DateHistogramAggregation) CalendarInterval(interval string) *DateHistogramAggregation {
+	delete(agg.kv, FixedInterval)
+	agg.kv[CalendarInterval] = interval
+	return agg
+}
+
+// FixedInterval sets an interval of a fixed length in SI units (e.g. 90m, 30d)
+func (agg *DateHistogramAggregation) FixedInterval(interval string) *DateHistogramAggregation {
+	delete(agg.kv, CalendarInterval)
+	agg.kv[FixedInterval] = interval
+	return agg
+}
+
+// TimeZone sets the time zone of the buckets (e.g. +01:00, Europe/Paris), UTC by default
+func (agg *DateHistogramAggregation) TimeZone(zone string) *DateHistogramAggregation {
+	agg.kv[TimeZone] = zone
+	return agg
+}
+
+// Format sets the format of the keys of the buckets (e.g. yyyy-MM-dd), in BucketResult.KeyAsString
+func (agg *DateHistogramAggregation) Format(format string) *DateHistogramAggregation {
+	agg.kv[Format] = format
+	return agg
+}
+
+// MinDocCount sets the minimum number of documents of the returned buckets, 0 returns the empty buckets
+func (agg *DateHistogramAggregation) MinDocCount(count int) *DateHistogramAggregation {
+	agg.kv[MinDocCount] = count
+	return agg
+}
+
+// ExtendedBounds returns the buckets between min and max even when they are empty (with MinDocCount(0)),
+// the bounds are dates in the format of the histogram or date math (e.g. now-1y/y)
+func (agg *DateHistogramAggregation) ExtendedBounds(min, max interface{}) *DateHistogramAggregation {
+	agg.kv[ExtendedBounds] = Dict{"min": min, "max": max}
+	return agg
+}
+
+// Offset shifts the bucket boundaries by the given duration (e.g. +6h to start days at 6am)
+func (agg *DateHistogramAggregation) Offset(offset string) *DateHistogramAggregation {
+	agg.kv[Offset] = offset
+	return agg
+}
+
+// Order sets the ordering of the buckets, key is _count, _key or the name of a sub metric and direction is asc or desc
+func (agg *DateHistogramAggregation) Order(key, direction string) *DateHistogramAggregation {
+	agg.kv[Order] = Dict{key: direction}
+	return agg
+}
+
+// AddAgg adds sub aggregations computed on each bucket
+func (agg *DateHistogramAggregation) AddAgg(aggs ...Agg) *DateHistogramAggregation {
+	agg.addAggs(aggs)
+	return agg
+}
+
+// RangeAggregation a structure representing the 'range' and 'date_range' buckets, a bucket per range of values
+type RangeAggregation struct {
+	*aggDefinition
+	ranges []Dict
+}
+
+// RangeAgg creates a new 'range' bucket on the given numeric field, the ranges are set with AddRange
+func RangeAgg(name, field string) *RangeAggregation {
+	agg := &RangeAggregation{aggDefinition: newAggDefinition(name, Range), ranges: []Dict{}}
+	agg.kv[Field] = field
+	agg.kv[Ranges] = agg.ranges
+	return agg
+}
+
+// DateRangeAgg creates a new 'date_range' bucket on the given date field, the bounds of the ranges are dates or date math (e.g. now-10M/M)
+func DateRangeAgg(name, field string) *RangeAggregation {
+	agg := RangeAgg(name, field)
+	agg.kind = DateRange
+	return agg
+}
+
+// AddRange adds a range from 'from' (included) to 'to' (excluded), a nil bound is unbounded
+func (agg *RangeAggregation) AddRange(from, to interface{}) *RangeAggregation {
+	return agg.AddKeyedRange("", from, to)
+}
+
+// AddKeyedRange adds a range with the given key, the key of its bucket instead of the bounds
+func (agg *RangeAggregation) AddKeyedRange(key string, from, to interface{}) *RangeAggregation {
+	r := make(Dict)
+	if key != "" {
+		r["key"] = key
+	}
+	if from != nil {
+		r["from"] = from
+	}
+	if to != nil {
+		r["to"] = to
+	}
+	agg.ranges = append(agg.ranges, r)
+	agg.kv[Ranges] = agg.ranges
+	return agg
+}
+
+// Format sets the format of the bounds of the ranges and of the keys of the buckets (e.g. MM-yyyy)
+func (agg *RangeAggregation) Format(format string) *RangeAggregation {
+	agg.kv[Format] = format
+	return agg
+}
+
+// TimeZone sets the time zone of the dates of a 'date_range' bucket (e.g. CET)
+func (agg *RangeAggregation) TimeZone(zone string) *RangeAggregation {
+	agg.kv[TimeZone] = zone
+	return agg
+}
+
+// Keyed returns the buckets as an object by key
+func (agg *RangeAggregation) Keyed(keyed bool) *RangeAggregation {
+	agg.kv[Keyed] = keyed
+	return agg
+}
+
+// AddAgg adds sub aggregations computed on each bucket
+func (agg *RangeAggregation) AddAgg(aggs ...Agg) *RangeAggregation {
+	agg.addAggs(aggs)
+	return agg
+}
+
+// FiltersAggregation a structure representing the 'filters' bucket, a bucket per named filter
+type FiltersAggregation struct {
+	*aggDefinition
+}
+
+// FiltersAgg creates a new 'filters' bucket, the filters are set with AddFilter
+func FiltersAgg(name string) *FiltersAggregation {
+	agg := &FiltersAggregation{newAggDefinition(name, Filters)}
+	agg.kv[Filters] = make(Dict)
+	return agg
+}
+
+// AddFilter adds a filter, its bucket has the given key
+func (agg *FiltersAggregation) AddFilter(key string, query Query) *FiltersAggregation {
+	agg.kv[Filters].(Dict)[key] = clause{query, true}
+	return agg
+}
+
+// OtherBucketKey adds a bucket with the given key for the documents matching none of the filters
+func (agg *FiltersAggregation) OtherBucketKey(key string) *FiltersAggregation {
+	agg.kv[OtherBucketKey] = key
+	return agg
+}
+
+// AddAgg adds sub aggregations computed on each bucket
+func (agg *FiltersAggregation) AddAgg(aggs ...Agg) *FiltersAggregation {
+	agg.addAggs(aggs)
+	return agg
+}
+
+// SingleBucketAggregation a structure representing the 'filter', 'global' and 'missing' buckets, a single bucket of documents
+type SingleBucketAggregation struct {
+	*aggDefinition
+}
+
+// FilterAgg creates a new 'filter' bucket of the documents of the scope matching the given query
+func FilterAgg(name string, query Query) *SingleBucketAggregation {
+	agg := &SingleBucketAggregation{newAggDefinition(name, FilterBucket)}
+	agg.query = query
+	return agg
+}
+
+// GlobalAgg creates a new 'global' bucket of all the documents of the index, whatever the query of the request
+func GlobalAgg(name string) *SingleBucketAggregation {
+	return &SingleBucketAggregation{newAggDefinition(name, Global)}
+}
+
+// MissingAgg creates a new 'missing' bucket of the documents without a value for the given field
+func MissingAgg(name, field string) *SingleBucketAggregation {
+	agg := &SingleBucketAggregation{newAggDefinition(name, Missing)}
+	agg.kv[Field] = field
+	return agg
+}
+
+// AddAgg adds sub aggregations computed on the bucket
+func (agg *SingleBucketAggregation) AddAgg(aggs ...Agg) *SingleBucketAggregation {
+	agg.addAggs(aggs)
+	return agg
+}
+
+// MetricAggregation a structure representing a metric on the values of a field (e.g. avg, sum, stats).
+// Metrics don't have sub aggregations, they are the leaves of the aggregation tree.
+type MetricAggregation struct {
+	*aggDefinition
+}
+
+// newMetric creates a new metric of the given kind on the given field
+func newMetric(name, kind, field string) *MetricAggregation {
+	agg := &MetricAggregation{newAggDefinition(name, kind)}
+	agg.kv[Field] = field
+	return agg
+}
+
+// AvgAgg creates a new 'avg' metric on the given field
+func AvgAgg(name, field string) *MetricAggregation {
+	return newMetric(name, Avg, field)
+}
+
+// SumAgg creates a new 'sum' metric on the given field
+func SumAgg(name, field string) *MetricAggregation {
+	return newMetric(name, Sum, field)
+}
+
+// MinAgg creates a new 'min' metric on the given field
+func MinAgg(name, field string) *MetricAggregation {
+	return newMetric(name, Min, field)
+}
+
+// MaxAgg creates a new 'max' metric on the given field
+func MaxAgg(name, field string) *MetricAggregation {
+	return newMetric(name, Max, field)
+}
+
+// StatsAgg creates a new 'stats' metric on the given field
+func StatsAgg(name, field string) *MetricAggregation {
+	return newMetric(name, Stats, field)
+}
+
+// ExtendedStatsAgg creates a new 'extended_stats' metric on the given field
+func ExtendedStatsAgg(name, field string) *MetricAggregation {
+	return newMetric(name, ExtendedStats, field)
+}
+
+// ValueCountAgg creates a new 'value_count' metric on the given field
+func ValueCountAgg(name, field string) *MetricAggregation {
+	return newMetric(name, ValueCount, field)
+}
+
+// Missing sets the value of the documents without the field, by default they are ignored
+func (agg *MetricAggregation) Missing(value interface{}) *MetricAggregation {
+	agg.kv[Missing] = value
+	return agg
+}
+
+// Format sets the format of the value, in ValueResult.ValueAsString
+func (agg *MetricAggregation) Format(format string) *MetricAggregation {
+	agg.kv[Format] = format
+	return agg
+}
+
+// Add adds a parameter to this metric (e.g. sigma for 'extended_stats')
+func (agg *MetricAggregation) Add(name string, value interface{}) *MetricAggregation {
+	agg.kv[name] = value
+	return agg
+}
+
+// CardinalityAggregation a structure representing the 'cardinality' metric, the approximate number of distinct values of a field
+type CardinalityAggregation struct {
+	*aggDefinition
+}
+
+// CardinalityAgg creates a new 'cardinality' metric on the given field
+func CardinalityAgg(name, field string) *CardinalityAggregation {
+	agg := &CardinalityAggregation{newAggDefinition(name, Cardiality)}
+	agg.kv[Field] = field
+	return agg
+}
+
+// PrecisionThreshold sets the count under which the cardinality is expected to be exact, at the cost of memory
+func (agg *CardinalityAggregation) PrecisionThreshold(threshold int) *CardinalityAggregation {
+	agg.kv[PrecisionThreshold] = threshold
+	return agg
+}
+
+// Missing sets the value of the documents without the field, by default they are ignored
+func (agg *CardinalityAggregation) Missing(value interface{}) *CardinalityAggregation {
+	agg.kv[Missing] = value
+	return agg
+}
+
+// PercentilesAggregation a structure representing the 'percentiles' and 'percentile_ranks' metrics
+type PercentilesAggregation struct {
+	*aggDefinition
+}
+
+// PercentilesAgg creates a new 'percentiles' metric on the given field, with the given percents (1, 5, 25, 50, 75, 95, 99 by default)
+func PercentilesAgg(name, field string, percents ...float64) *PercentilesAggregation {
+	agg := &PercentilesAggregation{newAggDefinition(name, Percentiles)}
+	agg.kv[Field] = field
+	if len(percents) > 0 {
+		agg.kv[Percents] = percents
+	}
+	return agg
+}
+
+// PercentileRanksAgg creates a new 'percentile_ranks' metric returning the percentile of each of the given values of the field
+func PercentileRanksAgg(name, field string, values ...float64) *PercentilesAggregation {
+	agg := &PercentilesAggregation{newAggDefinition(name, PercentileRanks)}
+	agg.kv[Field] = field
+	agg.kv[Values] = values
+	return agg
+}
+
+// Compression sets the accuracy of the approximation (100 by default), higher is more accurate and uses more memory
+func (agg *PercentilesAggregation) Compression(compression int) *PercentilesAggregation {
+	agg.kv["tdigest"] = Dict{Compression: compression}
+	return agg
+}
+
+// Missing sets the value of the documents without the field, by default they are ignored
+func (agg *PercentilesAggregation) Missing(value interface{}) *PercentilesAggregation {
+	agg.kv[Missing] = value
+	return agg
+}
+
+// TopHitsAggregation a structure representing the 'top_hits' metric, the top documents of a bucket
+type TopHitsAggregation struct {
+	*aggDefinition
+}
+
+// TopHitsAgg creates a new 'top_hits' metric, by default it returns the 3 most relevant documents
+func TopHitsAgg(name string) *TopHitsAggregation {
+	return &TopHitsAggregation{newAggDefinition(name, TopHits)}
+}
+
+// Size sets the number of documents to return
+func (agg *TopHitsAggregation) Size(size int) *TopHitsAggregation {
+	agg.kv[Size] = size
+	return agg
+}
+
+// From sets the offset of the first document to return
+func (agg *TopHitsAggregation) From(from int) *TopHitsAggregation {
+	agg.kv["from"] = from
+	return agg
+}
+
+// Sort adds a sort of the documents on the given field and order (i.e. asc or desc)
+func (agg *TopHitsAggregation) Sort(field, order string) *TopHitsAggregation {
+	sorts, _ := agg.kv[SORT].([]Dict)
+	agg.kv[SORT] = append(sorts, Dict{field: Dict{Order: order}})
+	return agg
+}
+
+// Source sets the fields of the source of the documents to return
+func (agg *TopHitsAggregation) Source(filter *SourceFilter) *TopHitsAggregation {
+	agg.kv[SOURCE] = filter.Value()
+	return agg
+}
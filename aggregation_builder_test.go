@@ -0,0 +1,66 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for typed aggregations
+func TestTypedAggregations(t *testing.T) {
+	actual := []string{
+		newAggs().AddAgg(TermsAgg("colors", "color").Size(5).Order("avg_price", "desc").AddAgg(AvgAgg("avg_price", "price"))).String(),
+		newAggs().Add(NewBucket("colors").AddTerm(Field, "color").AddAgg(MinAgg("min_price", "price"), MaxAgg("max_price", "price"))).String(),
+		HistogramAgg("price", "price", 20000).MinDocCount(0).ExtendedBounds(0, 100000).AddAgg(SumAgg("revenue", "price")).String(),
+		DateHistogramAgg("sales", "sold").CalendarInterval("month").TimeZone("Europe/Paris").Format("yyyy-MM-dd").MinDocCount(0).ExtendedBounds("2014-01-01", "2014-12-31").String(),
+		DateHistogramAgg("sales", "sold").CalendarInterval("month").FixedInterval("30d").String(),
+		RangeAgg("prices", "price").AddRange(nil, 20000).AddKeyedRange("expensive", 20000, nil).Keyed(true).AddAgg(StatsAgg("stats", "price")).String(),
+		DateRangeAgg("periods", "sold").AddRange("now-10M/M", "now").Format("MM-yyyy").String(),
+		FiltersAgg("messages").AddFilter("errors", MatchQuery("body", "error")).OtherBucketKey("other").AddAgg(ValueCountAgg("count", "body")).String(),
+		FilterAgg("ford", TermQuery("make", "ford")).AddAgg(ExtendedStatsAgg("spread", "price").Add("sigma", 3)).String(),
+		GlobalAgg("all").AddAgg(CardinalityAgg("makes", "make").PrecisionThreshold(100)).String(),
+		MissingAgg("no_color", "color").String(),
+		PercentilesAgg("load", "latency", 50, 99).Compression(200).String(),
+		PercentileRanksAgg("load", "latency", 210, 800).String(),
+		TopHitsAgg("cheapest").Size(1).Sort("price", "asc").Source(NewSourceFilter().Include("price")).String(),
+	}
+	expected := []string{
+		`{"aggs":{"colors":{"aggs":{"avg_price":{"avg":{"field":"price"}}},"terms":{"field":"color","order":{"avg_price":"desc"},"size":5}}}}`,
+		`{"aggs":{"colors":{"aggs":{"max_price":{"max":{"field":"price"}},"min_price":{"min":{"field":"price"}}},"terms":{"field":"color"}}}}`,
+		`{"price":{"aggs":{"revenue":{"sum":{"field":"price"}}},"histogram":{"extended_bounds":{"max":100000,"min":0},"field":"price","interval":20000,"min_doc_count":0}}}`,
+		`{"sales":{"date_histogram":{"calendar_interval":"month","extended_bounds":{"max":"2014-12-31","min":"2014-01-01"},"field":"sold","format":"yyyy-MM-dd","min_doc_count":0,"time_zone":"Europe/Paris"}}}`,
+		`{"sales":{"date_histogram":{"field":"sold","fixed_interval":"30d"}}}`,
+		`{"prices":{"aggs":{"stats":{"stats":{"field":"price"}}},"range":{"field":"price","keyed":true,"ranges":[{"to":20000},{"from":20000,"key":"expensive"}]}}}`,
+		`{"periods":{"date_range":{"field":"sold","format":"MM-yyyy","ranges":[{"from":"now-10M/M","to":"now"}]}}}`,
+		`{"messages":{"aggs":{"count":{"value_count":{"field":"body"}}},"filters":{"filters":{"errors":{"match":{"body":{"query":"error"}}}},"other_bucket_key":"other"}}}`,
+		`{"ford":{"aggs":{"spread":{"extended_stats":{"field":"price","sigma":3}}},"filter":{"term":{"make":{"value":"ford"}}}}}`,
+		`{"all":{"aggs":{"makes":{"cardinality":{"field":"make","precision_threshold":100}}},"global":{}}}`,
+		`{"no_color":{"missing":{"field":"color"}}}`,
+		`{"load":{"percentiles":{"field":"latency","percents":[50,99],"tdigest":{"compression":200}}}}`,
+		`{"load":{"percentile_ranks":{"field":"latency","values":[210,800]}}}`,
+		`{"cheapest":{"top_hits":{"_source":["price"],"size":1,"sort":[{"price":{"order":"asc"}}]}}}`,
+	}
+	equals(t, actual, expected)
+	// sub aggregations added to an aggregation after it was attached are rendered
+	terms := TermsAgg("colors", "color")
+	makes := TermsAgg("makes", "make")
+	filters := FiltersAgg("messages")
+	search := emptySearch().AddAgg(terms, filters)
+	terms.AddAgg(makes)
+	makes.AddAgg(AvgAgg("avg_price", "price"))
+	query := MatchQuery("body", "error")
+	filters.AddFilter("errors", query)
+	term := TermQuery("make", "ford")
+	filter := FilterAgg("ford", term)
+	search.AddAgg(filter)
+	query.Operator("and")
+	term.Boost(2)
+	equals(t, []string{String(search.query)}, []string{
+		`{"aggs":{"colors":{"aggs":{"makes":{"aggs":{"avg_price":{"avg":{"field":"price"}}},"terms":{"field":"make"}}},"terms":{"field":"color"}},` +
+			`"ford":{"filter":{"term":{"make":{"boost":2,"value":"ford"}}}},` +
+			`"messages":{"filters":{"filters":{"errors":{"match":{"body":{"operator":"and","query":"error"}}}}}}}}`,
+	})
+	// the filters of typed aggregations are linted like the others
+	search = emptySearch().AddAgg(FilterAgg("old", NewQuery("missing").Add(Field, "color")))
+	if issues := Lint(search); len(issues) != 1 || issues[0].Path != "aggs.old.filter.missing" {
+		t.Error("Should report the deprecated filter", issues)
+	}
+}
@@ -0,0 +1,571 @@
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// AggregationResults the results of aggregations by name, kept as raw JSON and decoded by the typed accessors.
+// Each accessor returns nil when there is no aggregation with the given name or when it has another kind,
+// the kind is recognized by the fields of its result (e.g. 'value' for single value metrics, 'buckets' for multi bucket aggregations).
+// The results of the other kinds of aggregations (e.g. matrix_stats) are read with Raw.
+type AggregationResults map[string]json.RawMessage
+
+// Aggs returns the results of the aggregations of this search result
+func (result SearchResult) Aggs() AggregationResults {
+	return result.Aggregations
+}
+
+// Raw returns the raw JSON of the result of an aggregation
+func (aggs AggregationResults) Raw(name string) json.RawMessage {
+	return aggs[name]
+}
+
+// decode decodes the result of an aggregation into the given structure, it returns false if it can't or if one of the given fields is missing
+func (aggs AggregationResults) decode(name string, value interface{}, required ...string) bool {
+	raw, ok := aggs[name]
+	if !ok {
+		return false
+	}
+	if len(required) > 0 {
+		fields := map[string]json.RawMessage{}
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return false
+		}
+		for _, field := range required {
+			if _, ok := fields[field]; !ok {
+				return false
+			}
+		}
+	}
+	return json.Unmarshal(raw, value) == nil
+}
+
+// buckets decodes the result of a multi bucket aggregation
+func (aggs AggregationResults) buckets(name string) *BucketsResult {
+	result := &BucketsResult{}
+	if !aggs.decode(name, result) || result.buckets == nil {
+		return nil
+	}
+	return result
+}
+
+// Terms returns the result of a 'terms' aggregation
+func (aggs AggregationResults) Terms(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// SignificantTerms returns the result of a 'significant_terms' aggregation
+func (aggs AggregationResults) SignificantTerms(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// Histogram returns the result of a 'histogram' aggregation
+func (aggs AggregationResults) Histogram(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// DateHistogram returns the result of a 'date_histogram' aggregation
+func (aggs AggregationResults) DateHistogram(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// Range returns the result of a 'range' aggregation
+func (aggs AggregationResults) Range(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// DateRange returns the result of a 'date_range' aggregation
+func (aggs AggregationResults) DateRange(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// Filters returns the result of a 'filters' aggregation, the key of each bucket is the name of its filter when they are named
+func (aggs AggregationResults) Filters(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// IPRange returns the result of an 'ip_range' aggregation, the bounds of its buckets are in FromAsString and ToAsString
+func (aggs AggregationResults) IPRange(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// GeoDistance returns the result of a 'geo_distance' aggregation
+func (aggs AggregationResults) GeoDistance(name string) *BucketsResult {
+	return aggs.buckets(name)
+}
+
+// Composite returns the result of a 'composite' aggregation, the key of each bucket is an object with a value per source
+func (aggs AggregationResults) Composite(name string) *CompositeResult {
+	result := &CompositeResult{}
+	if !aggs.decode(name, result) || result.buckets == nil {
+		return nil
+	}
+	return result
+}
+
+// single decodes the result of a single bucket aggregation
+func (aggs AggregationResults) single(name string) *SingleBucketResult {
+	result := &SingleBucketResult{}
+	if !aggs.decode(name, result) {
+		return nil
+	}
+	return result
+}
+
+// Filter returns the result of a 'filter' aggregation
+func (aggs AggregationResults) Filter(name string) *SingleBucketResult {
+	return aggs.single(name)
+}
+
+// Global returns the result of a 'global' aggregation
+func (aggs AggregationResults) Global(name string) *SingleBucketResult {
+	return aggs.single(name)
+}
+
+// Missing returns the result of a 'missing' aggregation
+func (aggs AggregationResults) Missing(name string) *SingleBucketResult {
+	return aggs.single(name)
+}
+
+// Nested returns the result of a 'nested' aggregation
+func (aggs AggregationResults) Nested(name string) *SingleBucketResult {
+	return aggs.single(name)
+}
+
+// ReverseNested returns the result of a 'reverse_nested' aggregation
+func (aggs AggregationResults) ReverseNested(name string) *SingleBucketResult {
+	return aggs.single(name)
+}
+
+// Children returns the result of a 'children' aggregation
+func (aggs AggregationResults) Children(name string) *SingleBucketResult {
+	return aggs.single(name)
+}
+
+// Sampler returns the result of a 'sampler' (or 'diversified_sampler') aggregation
+func (aggs AggregationResults) Sampler(name string) *SingleBucketResult {
+	return aggs.single(name)
+}
+
+// value decodes the result of a single value metric
+func (aggs AggregationResults) value(name string) *ValueResult {
+	result := &ValueResult{}
+	if !aggs.decode(name, result, "value") {
+		return nil
+	}
+	return result
+}
+
+// Avg returns the result of an 'avg' metric
+func (aggs AggregationResults) Avg(name string) *ValueResult {
+	return aggs.value(name)
+}
+
+// Sum returns the result of a 'sum' metric
+func (aggs AggregationResults) Sum(name string) *ValueResult {
+	return aggs.value(name)
+}
+
+// Min returns the result of a 'min' metric
+func (aggs AggregationResults) Min(name string) *ValueResult {
+	return aggs.value(name)
+}
+
+// Max returns the result of a 'max' metric
+func (aggs AggregationResults) Max(name string) *ValueResult {
+	return aggs.value(name)
+}
+
+// ValueCount returns the result of a 'value_count' metric
+func (aggs AggregationResults) ValueCount(name string) *ValueResult {
+	return aggs.value(name)
+}
+
+// Cardinality returns the result of a 'cardinality' metric
+func (aggs AggregationResults) Cardinality(name string) *ValueResult {
+	return aggs.value(name)
+}
+
+// Stats returns the result of a 'stats' metric
+func (aggs AggregationResults) Stats(name string) *StatsResult {
+	result := &StatsResult{}
+	if !aggs.decode(name, result, "count", "sum") {
+		return nil
+	}
+	return result
+}
+
+// ExtendedStats returns the result of an 'extended_stats' metric
+func (aggs AggregationResults) ExtendedStats(name string) *ExtendedStatsResult {
+	result := &ExtendedStatsResult{}
+	if !aggs.decode(name, result, "count", "sum_of_squares") {
+		return nil
+	}
+	return result
+}
+
+// Percentiles returns the result of a 'percentiles' metric
+func (aggs AggregationResults) Percentiles(name string) *PercentilesResult {
+	result := &PercentilesResult{}
+	if !aggs.decode(name, result, "values") {
+		return nil
+	}
+	return result
+}
+
+// PercentileRanks returns the result of a 'percentile_ranks' metric
+func (aggs AggregationResults) PercentileRanks(name string) *PercentilesResult {
+	return aggs.Percentiles(name)
+}
+
+// TopHits returns the result of a 'top_hits' metric
+func (aggs AggregationResults) TopHits(name string) *TopHitsResult {
+	result := &TopHitsResult{}
+	if !aggs.decode(name, result, "hits") {
+		return nil
+	}
+	return result
+}
+
+// GeoBounds returns the result of a 'geo_bounds' metric
+func (aggs AggregationResults) GeoBounds(name string) *GeoBoundsResult {
+	result := &GeoBoundsResult{}
+	if !aggs.decode(name, result, "bounds") {
+		return nil
+	}
+	return result
+}
+
+// GeoCentroid returns the result of a 'geo_centroid' metric
+func (aggs AggregationResults) GeoCentroid(name string) *GeoCentroidResult {
+	result := &GeoCentroidResult{}
+	if !aggs.decode(name, result, "location") {
+		return nil
+	}
+	return result
+}
+
+// ScriptedMetric returns the result of a 'scripted_metric' aggregation
+func (aggs AggregationResults) ScriptedMetric(name string) *ScriptedMetricResult {
+	result := &ScriptedMetricResult{}
+	if !aggs.decode(name, result, "value") {
+		return nil
+	}
+	return result
+}
+
+// subAggregations returns the sub aggregations of a bucket, i.e. its fields holding objects except the given ones
+func subAggregations(fields map[string]json.RawMessage, known ...string) AggregationResults {
+	aggs := make(AggregationResults)
+	for name, raw := range fields {
+		if containsString(known, name) || len(raw) == 0 || raw[0] != '{' {
+			continue
+		}
+		aggs[name] = raw
+	}
+	return aggs
+}
+
+// BucketsResult is a structure representing the result of a multi bucket aggregation (e.g. terms, histogram, range)
+// e.g. {"doc_count_error_upper_bound":0,"sum_other_doc_count":0,"buckets":[{"key":"red","doc_count":4,"avg_price":{"value":32500.0}}]}
+type BucketsResult struct {
+	DocCountErrorUpperBound int64
+	SumOtherDocCount        int64
+	buckets                 []BucketResult
+}
+
+// UnmarshalJSON decodes the result of a multi bucket aggregation, the buckets are given as an array or as an object keyed by bucket key
+func (result *BucketsResult) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		DocCountErrorUpperBound int64           `json:"doc_count_error_upper_bound"`
+		SumOtherDocCount        int64           `json:"sum_other_doc_count"`
+		Buckets                 json.RawMessage `json:"buckets"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	result.DocCountErrorUpperBound = raw.DocCountErrorUpperBound
+	result.SumOtherDocCount = raw.SumOtherDocCount
+	if len(raw.Buckets) == 0 {
+		return nil
+	}
+	if raw.Buckets[0] == '[' {
+		return json.Unmarshal(raw.Buckets, &result.buckets)
+	}
+	// keyed buckets are decoded one by one to keep the order of the response (e.g. the order of the ranges)
+	decoder := json.NewDecoder(bytes.NewReader(raw.Buckets))
+	if _, err := decoder.Token(); err != nil {
+		return err
+	}
+	result.buckets = []BucketResult{}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		bucket := BucketResult{}
+		if err := decoder.Decode(&bucket); err != nil {
+			return err
+		}
+		if bucket.Key == nil {
+			bucket.Key = token
+		}
+		result.buckets = append(result.buckets, bucket)
+	}
+	return nil
+}
+
+// Buckets returns the buckets of this aggregation, keyed buckets are in the order of the response
+func (result *BucketsResult) Buckets() []BucketResult {
+	return result.buckets
+}
+
+// Bucket returns the bucket with the given key (e.g. "red", "1420070400000"), nil if there is none
+func (result *BucketsResult) Bucket(key string) *BucketResult {
+	for i := range result.buckets {
+		if result.buckets[i].KeyString() == key {
+			return &result.buckets[i]
+		}
+	}
+	return nil
+}
+
+// BucketResult is a structure representing a bucket of a multi bucket aggregation, with the results of its sub aggregations
+// e.g. {"key":1420070400000,"key_as_string":"2015-01-01","doc_count":2,"sum_price":{"value":30000.0}}
+type BucketResult struct {
+	// Key the key of the bucket, a string (e.g. for terms) or a number (e.g. for histograms)
+	Key         interface{}
+	KeyAsString string
+	DocCount    int64
+	// From and To the bounds of the buckets of range aggregations, the bounds of 'ip_range' buckets are only in FromAsString and ToAsString
+	From         *float64
+	To           *float64
+	FromAsString string
+	ToAsString   string
+	aggs         AggregationResults
+}
+
+// UnmarshalJSON decodes a bucket, its fields holding objects are the results of its sub aggregations
+func (bucket *BucketResult) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	raw := struct {
+		Key          interface{} `json:"key"`
+		KeyAsString  string      `json:"key_as_string"`
+		DocCount     int64       `json:"doc_count"`
+		From         interface{} `json:"from"`
+		To           interface{} `json:"to"`
+		FromAsString string      `json:"from_as_string"`
+		ToAsString   string      `json:"to_as_string"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	*bucket = BucketResult{
+		Key: raw.Key, KeyAsString: raw.KeyAsString, DocCount: raw.DocCount,
+		FromAsString: raw.FromAsString, ToAsString: raw.ToAsString,
+		aggs: subAggregations(fields, "key"),
+	}
+	// the bounds are numbers, except for 'ip_range' buckets whose bounds are addresses
+	bucket.From, bucket.FromAsString = rangeBound(raw.From, raw.FromAsString)
+	bucket.To, bucket.ToAsString = rangeBound(raw.To, raw.ToAsString)
+	return nil
+}
+
+// rangeBound returns a bound of a range bucket as a number and as a string
+func rangeBound(value interface{}, formatted string) (*float64, string) {
+	switch v := value.(type) {
+	case float64:
+		return &v, formatted
+	case string:
+		return nil, v
+	}
+	return nil, formatted
+}
+
+// KeyString returns the key of this bucket as a string, the formatted key when there is one
+func (bucket *BucketResult) KeyString() string {
+	if bucket.KeyAsString != "" {
+		return bucket.KeyAsString
+	}
+	switch key := bucket.Key.(type) {
+	case string:
+		return key
+	case float64:
+		return strconv.FormatFloat(key, 'f', -1, 64)
+	case nil:
+		return ""
+	}
+	return fmt.Sprint(bucket.Key)
+}
+
+// Aggs returns the results of the sub aggregations of this bucket
+func (bucket *BucketResult) Aggs() AggregationResults {
+	return bucket.aggs
+}
+
+// SingleBucketResult is a structure representing the result of a single bucket aggregation (e.g. filter, global, missing)
+// e.g. {"doc_count":3,"avg_price":{"value":56000.0}}
+type SingleBucketResult struct {
+	DocCount int64
+	aggs     AggregationResults
+}
+
+// UnmarshalJSON decodes a single bucket, its fields holding objects are the results of its sub aggregations
+func (bucket *SingleBucketResult) UnmarshalJSON(data []byte) error {
+	fields := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	count, ok := fields["doc_count"]
+	if !ok {
+		return fmt.Errorf("not a single bucket aggregation: %s", string(data))
+	}
+	if err := json.Unmarshal(count, &bucket.DocCount); err != nil {
+		return err
+	}
+	bucket.aggs = subAggregations(fields)
+	return nil
+}
+
+// Aggs returns the results of the sub aggregations of this bucket
+func (bucket *SingleBucketResult) Aggs() AggregationResults {
+	return bucket.aggs
+}
+
+// ValueResult is a structure representing the result of a single value metric (e.g. avg, sum, cardinality)
+// e.g. {"value":32500.0} or {"value":1.4200704E12,"value_as_string":"2015-01-01"}
+type ValueResult struct {
+	// Value the value of the metric, nil when it has no value (e.g. the average of an empty bucket)
+	Value         *float64 `json:"value"`
+	ValueAsString string   `json:"value_as_string"`
+}
+
+// StatsResult is a structure representing the result of a 'stats' metric
+// e.g. {"count":7,"min":10000.0,"max":80000.0,"avg":26500.0,"sum":185500.0}
+type StatsResult struct {
+	Count int64   `json:"count"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Avg   float64 `json:"avg"`
+	Sum   float64 `json:"sum"`
+}
+
+// ExtendedStatsResult is a structure representing the result of an 'extended_stats' metric
+// e.g. {"count":7,"min":10000.0,"max":80000.0,"avg":26500.0,"sum":185500.0,"sum_of_squares":7.5E9,"variance":3.7E8,"std_deviation":19324.8,"std_deviation_bounds":{"upper":65149.6,"lower":-12149.6}}
+type ExtendedStatsResult struct {
+	StatsResult
+	SumOfSquares       float64 `json:"sum_of_squares"`
+	Variance           float64 `json:"variance"`
+	StdDeviation       float64 `json:"std_deviation"`
+	StdDeviationBounds struct {
+		Upper float64 `json:"upper"`
+		Lower float64 `json:"lower"`
+	} `json:"std_deviation_bounds"`
+}
+
+// PercentilesResult is a structure representing the result of a 'percentiles' or 'percentile_ranks' metric
+// e.g. {"values":{"1.0":5.0,"5.0":25.0,"25.0":165.0,"50.0":445.0,"75.0":725.0,"95.0":945.0,"99.0":985.0}}
+// or {"values":[{"key":50.0,"value":445.0},{"key":99.0,"value":985.0}]} when it's not keyed
+type PercentilesResult struct {
+	Values map[string]float64
+}
+
+// UnmarshalJSON decodes the values of percentiles given as an object by percent or as an array (i.e. keyed false)
+func (result *PercentilesResult) UnmarshalJSON(data []byte) error {
+	raw := struct {
+		Values json.RawMessage `json:"values"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	result.Values = map[string]float64{}
+	if len(raw.Values) == 0 || raw.Values[0] != '[' {
+		return json.Unmarshal(raw.Values, &result.Values)
+	}
+	values := []struct {
+		Key   float64  `json:"key"`
+		Value *float64 `json:"value"`
+	}{}
+	if err := json.Unmarshal(raw.Values, &values); err != nil {
+		return err
+	}
+	for _, value := range values {
+		// the keys are formatted like the keys of keyed percentiles, e.g. 50.0
+		key := strconv.FormatFloat(value.Key, 'f', -1, 64)
+		if !strings.Contains(key, ".") {
+			key += ".0"
+		}
+		if value.Value != nil {
+			result.Values[key] = *value.Value
+		}
+	}
+	return nil
+}
+
+// Value returns the value of the given percentile (or the percentile of the given value for percentile ranks), false if it was not computed
+func (result *PercentilesResult) Value(percent float64) (float64, bool) {
+	for key, value := range result.Values {
+		if k, err := strconv.ParseFloat(key, 64); err == nil && k == percent {
+			return value, true
+		}
+	}
+	return 0, false
+}
+
+// TopHitsResult is a structure representing the result of a 'top_hits' metric
+// e.g. {"hits":{"total":3,"max_score":1.0,"hits":[{"_index":"cars","_id":"1","_score":1.0,"_source":{"price":10000}}]}}
+type TopHitsResult struct {
+	Hits Hits `json:"hits"`
+}
+
+// GeoBoundsResult is a structure representing the result of a 'geo_bounds' metric
+// e.g. {"bounds":{"top_left":{"lat":48.86,"lon":2.32},"bottom_right":{"lat":48.83,"lon":2.37}}}
+type GeoBoundsResult struct {
+	Bounds struct {
+		TopLeft     GeoPoint `json:"top_left"`
+		BottomRight GeoPoint `json:"bottom_right"`
+	} `json:"bounds"`
+}
+
+// GeoCentroidResult is a structure representing the result of a 'geo_centroid' metric
+// e.g. {"location":{"lat":51.00982965203002,"lon":3.9662131341174245},"count":6}
+type GeoCentroidResult struct {
+	Location GeoPoint `json:"location"`
+	Count    int64    `json:"count"`
+}
+
+// ScriptedMetricResult is a structure representing the result of a 'scripted_metric' aggregation, its value is the result of the reduce script
+// e.g. {"value":430}
+type ScriptedMetricResult struct {
+	Value interface{} `json:"value"`
+}
+
+// CompositeResult is a structure representing the result of a 'composite' aggregation, a page of buckets
+// e.g. {"after_key":{"product":"mad max"},"buckets":[{"key":{"product":"mad max"},"doc_count":1}]}
+type CompositeResult struct {
+	BucketsResult
+	// AfterKey the key to pass as 'after' to get the next page of buckets, nil on the last page
+	AfterKey Dict
+}
+
+// UnmarshalJSON decodes the page of buckets and the key of the next page
+func (result *CompositeResult) UnmarshalJSON(data []byte) error {
+	if err := result.BucketsResult.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	raw := struct {
+		AfterKey Dict `json:"after_key"`
+	}{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	result.AfterKey = raw.AfterKey
+	return nil
+}
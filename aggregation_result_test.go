@@ -0,0 +1,107 @@
+package elastic
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// test for navigating the results of aggregations
+func TestAggregationResults(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(map[string]string{
+		"GET /cars/transactions/_search": `{"took":4,"timed_out":false,"_shards":{"total":5,"successful":5,"failed":0},"hits":{"total":7,"max_score":0.0,"hits":[]},"aggregations":{` +
+			`"colors":{"doc_count_error_upper_bound":0,"sum_other_doc_count":0,"buckets":[{"key":"blue","doc_count":1,"avg_price":{"value":15000.0}},{"key":"red","doc_count":4,"avg_price":{"value":32500.0},"makes":{"buckets":[{"key":"honda","doc_count":3}]}}]},` +
+			`"sales":{"buckets":[{"key_as_string":"2014-01-01","key":1388534400000,"doc_count":1,"revenue":{"value":15000.0}}]},` +
+			`"ranges":{"buckets":{"cheap":{"to":20000.0,"doc_count":3},"expensive":{"from":20000.0,"doc_count":4}}},` +
+			`"ford":{"doc_count":2,"stats":{"count":2,"min":25000.0,"max":30000.0,"avg":27500.0,"sum":55000.0}},` +
+			`"spread":{"count":7,"min":10000.0,"max":80000.0,"avg":26500.0,"sum":185500.0,"sum_of_squares":7.5E9,"variance":3.7E8,"std_deviation":19324.8,"std_deviation_bounds":{"upper":65149.6,"lower":-12149.6}},` +
+			`"load_times":{"values":{"50.0":445.0,"99.0":985.0}},` +
+			`"distinct_colors":{"value":3},` +
+			`"cheapest":{"hits":{"total":7,"max_score":null,"hits":[{"_index":"cars","_type":"transactions","_id":"1","_score":null,"_source":{"price":10000}}]}},` +
+			`"latencies":{"values":[{"key":50.0,"value":445.0},{"key":99.9,"value":990.0}]},` +
+			`"ips":{"buckets":[{"key":"*-10.0.0.5","to":"10.0.0.5","doc_count":10},{"key":"10.0.0.5-*","from":"10.0.0.5","doc_count":260}]},` +
+			`"pages":{"after_key":{"make":"honda"},"buckets":[{"key":{"make":"ford"},"doc_count":2},{"key":{"make":"honda"},"doc_count":3}]},` +
+			`"viewport":{"bounds":{"top_left":{"lat":48.86,"lon":2.32},"bottom_right":{"lat":48.83,"lon":2.37}}},` +
+			`"centroid":{"location":{"lat":51.0,"lon":3.9},"count":6},` +
+			`"profit":{"value":240.0},` +
+			`"sample":{"doc_count":200,"keywords":{"buckets":[{"key":"elasticsearch","doc_count":150}]}},` +
+			`"prices":{"buckets":{"5.0":{"key":5.0,"doc_count":1},"20.0":{"key":20.0,"doc_count":2},"100.0":{"key":100.0,"doc_count":3}}},` +
+			`"empty_avg":{"value":null}}}`,
+	}, &requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	res, err := client.Aggs("cars", "transactions").Add(NewBucket("colors").AddTerm(Field, "color")).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	aggs := res.Aggs()
+	colors := aggs.Terms("colors").Buckets()
+	sales := aggs.DateHistogram("sales").Buckets()[0]
+	ranges := aggs.Range("ranges").Buckets()
+	spread := aggs.ExtendedStats("spread")
+	p99, _ := aggs.Percentiles("load_times").Value(99)
+	// keyed buckets are in the order of the response
+	prices := aggs.Histogram("prices").Buckets()
+	actual := []string{
+		fmt.Sprint(len(colors), " ", colors[1].KeyString(), " ", colors[1].DocCount),
+		fmt.Sprint(*colors[1].Aggs().Avg("avg_price").Value),
+		fmt.Sprint(colors[1].Aggs().Terms("makes").Bucket("honda").DocCount),
+		fmt.Sprint(*aggs.Terms("colors").Bucket("blue").Aggs().Avg("avg_price").Value),
+		fmt.Sprint(sales.KeyString(), " ", sales.Key, " ", *sales.Aggs().Sum("revenue").Value),
+		fmt.Sprint(ranges[0].KeyString(), " ", *ranges[0].To, " ", ranges[1].KeyString(), " ", *ranges[1].From),
+		fmt.Sprint(aggs.Filter("ford").DocCount, " ", aggs.Filter("ford").Aggs().Stats("stats").Avg),
+		fmt.Sprint(spread.Max, " ", spread.StdDeviationBounds.Upper),
+		fmt.Sprint(p99),
+		fmt.Sprint(*aggs.Cardinality("distinct_colors").Value),
+		aggs.TopHits("cheapest").Hits.Hits[0].ID,
+		string(aggs.Raw("distinct_colors")),
+		fmt.Sprint(aggs.Percentiles("latencies").Values),
+		fmt.Sprint(aggs.IPRange("ips").Buckets()[0].ToAsString, " ", aggs.IPRange("ips").Buckets()[1].FromAsString, " ", aggs.IPRange("ips").Buckets()[1].DocCount),
+		fmt.Sprint(aggs.Composite("pages").AfterKey, " ", aggs.Composite("pages").Buckets()[0].Key),
+		fmt.Sprint(aggs.GeoBounds("viewport").Bounds.TopLeft.Lat, " ", aggs.GeoBounds("viewport").Bounds.BottomRight.Lon),
+		fmt.Sprint(aggs.GeoCentroid("centroid").Location.Lon, " ", aggs.GeoCentroid("centroid").Count),
+		fmt.Sprint(aggs.ScriptedMetric("profit").Value),
+		fmt.Sprint(aggs.Sampler("sample").DocCount, " ", aggs.Sampler("sample").Aggs().SignificantTerms("keywords").Bucket("elasticsearch").DocCount),
+		fmt.Sprint(prices[0].Key, " ", prices[1].Key, " ", prices[2].Key),
+		fmt.Sprint(aggs.Avg("empty_avg") != nil, " ", aggs.Avg("empty_avg").Value == nil),
+	}
+	expected := []string{
+		"2 red 4",
+		"32500",
+		"3",
+		"15000",
+		"2014-01-01 1.3885344e+12 15000",
+		"cheap 20000 expensive 20000",
+		"2 27500",
+		"80000 65149.6",
+		"985",
+		"3",
+		"1",
+		`{"value":3}`,
+		"map[50.0:445 99.9:990]",
+		"10.0.0.5 10.0.0.5 260",
+		"map[make:honda] map[make:ford]",
+		"48.86 2.37",
+		"3.9 6",
+		"240",
+		"200 150",
+		"5 20 100",
+		"true true",
+	}
+	equals(t, actual, expected)
+	// missing or mismatching aggregations
+	mismatches := []interface{}{
+		aggs.Terms("unknown"), aggs.Terms("distinct_colors"), aggs.Filter("colors"), aggs.Percentiles("spread"),
+		aggs.Avg("colors"), aggs.Avg("ford"), aggs.Stats("distinct_colors"), aggs.Stats("colors"), aggs.ExtendedStats("colors"),
+		aggs.ExtendedStats("ford"), aggs.TopHits("distinct_colors"), aggs.TopHits("colors"), aggs.GeoBounds("centroid"),
+		aggs.GeoCentroid("viewport"), aggs.ScriptedMetric("ford"), aggs.Composite("distinct_colors"),
+	}
+	for i, result := range mismatches {
+		if !reflect.ValueOf(result).IsNil() {
+			t.Errorf("Should not find aggregation %d: %v", i, result)
+		}
+	}
+	equals(t, requests, []string{"GET /cars/transactions/_search"})
+}
@@ -33,7 +33,7 @@ func TestAggregations(t *testing.T) {
 func TestQueryScope(t *testing.T) {
 	actual := []string{
 		newAggs().AddQuery(NewMatch().Add("make", "ford")).Add(NewBucket("colors").AddTerm(Field, "color")).String(),
-		newAggs().AddQuery(NewQuery("filtered").AddQuery(NewQuery("filter").AddQuery(NewQuery("rage").AddQuery(NewQuery("price").Add("gte", 10000))))).Add(NewBucket("single_avg_price").AddMetric(Avg, Field, "price")).String(),
+		newAggs().AddQuery(NewQuery("filtered").SetQuery(NewQuery("filter").SetQuery(NewQuery("rage").SetQuery(NewQuery("price").Add("gte", 10000))))).Add(NewBucket("single_avg_price").AddMetric(Avg, Field, "price")).String(),
 	}
 	expected := []string{
 		`{"aggs":{"colors":{"terms":{"field":"color"}}},"query":{"match":{"make":"ford"}}}`,
@@ -1,10 +1,9 @@
 package elastic
 
 import (
-	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"log"
 )
 
@@ -14,8 +13,10 @@ const (
 )
 
 type Alias struct {
-	url  string
-	dict Dict
+	client *Elasticsearch
+	parser *IndexResultParser
+	url    string
+	dict   Dict
 }
 
 /*
@@ -38,7 +39,12 @@ func newAlias() *Alias {
 
 func (client *Elasticsearch) Alias() *Alias {
 	url := fmt.Sprintf("http://%s/%s", client.Addr, ALIASES)
-	return &Alias{url: url, dict: make(Dict)}
+	return &Alias{
+		client: client,
+		parser: &IndexResultParser{},
+		url:    url,
+		dict:   make(Dict),
+	}
 }
 
 /*
@@ -59,15 +65,13 @@ func (alias *Alias) AddAction(operation, index, name string) *Alias {
  * POST /:index
  */
 func (alias *Alias) Post() {
-	log.Println("POST", alias.url)
-	body := String(alias.dict)
-	reader, err := exec("POST", alias.url, bytes.NewReader([]byte(body)))
-	if err != nil {
+	if err := alias.post(context.Background()); err != nil {
 		log.Println(err)
-		return
-	}
-	if data, err := ioutil.ReadAll(reader); err == nil {
-		fmt.Println(string(data))
 	}
+}
 
+// post submits the Aliases operation, it returns an error if the actions were not applied
+func (alias *Alias) post(ctx context.Context) error {
+	body := String(alias.dict)
+	return checkResult(alias.client.execute(ctx, "POST", alias.url, body, alias.parser))
 }
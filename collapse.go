@@ -0,0 +1,65 @@
+package elastic
+
+import ()
+
+const (
+	// COLLAPSE constant name of the field collapsing parameter of a Search API query
+	COLLAPSE = "collapse"
+	// MaxConcurrentGroupSearches a collapse parameter limiting the number of concurrent requests retrieving the inner hits of the groups
+	MaxConcurrentGroupSearches = "max_concurrent_group_searches"
+)
+
+// Collapse a structure representing the collapsing of search results on a field, i.e. one hit per value of the field
+type Collapse struct {
+	field     string
+	innerHits []*InnerHits
+	kv        Dict
+}
+
+// NewCollapse creates a new collapsing of the search results on the given field, it must be a keyword or numeric field with doc values
+func NewCollapse(field string) *Collapse {
+	return &Collapse{field: field, kv: make(Dict)}
+}
+
+// InnerHits adds the inner hits retrieved for each group (e.g. the 3 most recent hits of each group)
+func (collapse *Collapse) InnerHits(inner ...*InnerHits) *Collapse {
+	collapse.innerHits = append(collapse.innerHits, inner...)
+	return collapse
+}
+
+// MaxConcurrentGroupSearches sets the number of concurrent requests retrieving the inner hits of the groups
+func (collapse *Collapse) MaxConcurrentGroupSearches(max int) *Collapse {
+	collapse.kv[MaxConcurrentGroupSearches] = max
+	return collapse
+}
+
+// Dict returns the collapse definition as a dictionary, a single inner hits definition is rendered as an object
+func (collapse *Collapse) Dict() Dict {
+	dict := Dict{Field: collapse.field}
+	for k, v := range collapse.kv {
+		dict[k] = v
+	}
+	switch len(collapse.innerHits) {
+	case 0:
+	case 1:
+		dict[INNERHITS] = collapse.innerHits[0].kv
+	default:
+		inner := []Dict{}
+		for _, hits := range collapse.innerHits {
+			inner = append(inner, hits.kv)
+		}
+		dict[INNERHITS] = inner
+	}
+	return dict
+}
+
+// String returns a string representation of this collapse definition
+func (collapse *Collapse) String() string {
+	return String(collapse.Dict())
+}
+
+// Collapse collapses the search results on a field
+func (search *Search) Collapse(collapse *Collapse) *Search {
+	search.query[COLLAPSE] = collapse.Dict()
+	return search
+}
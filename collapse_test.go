@@ -0,0 +1,33 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for collapsing search results
+func TestCollapse(t *testing.T) {
+	actual := []string{
+		NewCollapse("family").String(),
+		NewCollapse("family").InnerHits(NewInnerHits().Name("recent").Size(3).AddSort(NewSort("date").Order(Desc))).MaxConcurrentGroupSearches(4).String(),
+		NewCollapse("family").InnerHits(NewInnerHits().Name("cheapest").Size(1), NewInnerHits().Name("newest").Size(1)).String(),
+		newSearch(nil, "").Collapse(NewCollapse("family")).String(),
+	}
+	expected := []string{
+		`{"field":"family"}`,
+		`{"field":"family","inner_hits":{"name":"recent","size":3,"sort":[{"date":{"order":"desc"}}]},"max_concurrent_group_searches":4}`,
+		`{"field":"family","inner_hits":[{"name":"cheapest","size":1},{"name":"newest","size":1}]}`,
+		`{"collapse":{"field":"family"}}`,
+	}
+	equals(t, actual, expected)
+	// parse collapsed hits
+	data := `{"took":1,"hits":{"total":2,"hits":[{"_index":"products","_id":"1","_score":1.0,"_source":{"family":"phone"},"fields":{"family":["phone"]},"inner_hits":{"recent":{"hits":{"total":2,"hits":[{"_index":"products","_id":"1","_score":1.0,"_source":{"family":"phone"}}]}}}}]}}`
+	parser := &SearchResultParser{}
+	result, err := parser.Parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hit := result.(SearchResult).Hits.Hits[0]
+	if hit.Fields["family"][0] != "phone" || hit.InnerHits["recent"].Hits.Total != 2 {
+		t.Error("Unexpected hit", hit)
+	}
+}
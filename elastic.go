@@ -2,6 +2,7 @@ package elastic
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -33,13 +34,18 @@ func (client *Elasticsearch) request(index, class string, id int64, request stri
 
 // Execute an HTTP request and parse the response
 func (client *Elasticsearch) Execute(method, url, query string, parser Parser) (interface{}, error) {
+	return client.execute(context.Background(), method, url, query, parser)
+}
+
+// execute an HTTP request that is aborted when the given context is done, and parse the response
+func (client *Elasticsearch) execute(ctx context.Context, method, url, query string, parser Parser) (interface{}, error) {
 	var body io.Reader
 	if query != "" {
 		body = bytes.NewReader([]byte(query))
 	}
 	// submit the request
 	log.Println(method, url, query)
-	reader, err := exec(method, url, body)
+	reader, err := execContext(ctx, method, url, body)
 	if err != nil {
 		log.Println(err)
 		return nil, err
@@ -52,6 +58,17 @@ func (client *Elasticsearch) Execute(method, url, query string, parser Parser) (
 	return nil, err
 }
 
+// checkResult returns an error if the request could not be executed or if Elasticsearch responded with a failure
+func checkResult(result interface{}, err error) error {
+	if err != nil {
+		return err
+	}
+	if failure, ok := result.(Failure); ok {
+		return failure
+	}
+	return nil
+}
+
 // String returns a string representation of the dictionary
 func String(obj interface{}) string {
 	marshaled, err := json.Marshal(obj)
@@ -87,7 +104,12 @@ func urlString(prefix string, params map[string]string) string {
 
 // Execute a REST request
 func exec(method, url string, body io.Reader) (io.Reader, error) {
-	req, err := http.NewRequest(method, url, body)
+	return execContext(context.Background(), method, url, body)
+}
+
+// execContext executes a REST request that is aborted when the given context is done
+func execContext(ctx context.Context, method, url string, body io.Reader) (io.Reader, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
 	if err != nil {
 		return nil, err
 	}
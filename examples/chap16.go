@@ -20,20 +20,20 @@ func chap16() {
 	time.Sleep(1 * time.Second)
 
 	// prefix query
-	c.Search("my_index", "address").AddQuery(e.NewQuery("query").AddQuery(e.NewQuery(e.Prefix).Add("postcode", "W1"))).Get()
+	c.Search("my_index", "address").AddQuery(e.NewQuery("query").SetQuery(e.NewQuery(e.Prefix).Add("postcode", "W1"))).Get()
 
 	// wildcard query: ? match any character, * matches zero or more
-	c.Search("my_index", "address").AddQuery(e.NewQuery("query").AddQuery(e.NewQuery(e.Wildcard).Add("postcode", "W?F*HW"))).Get()
+	c.Search("my_index", "address").AddQuery(e.NewQuery("query").SetQuery(e.NewQuery(e.Wildcard).Add("postcode", "W?F*HW"))).Get()
 	// regular expression query
-	c.Search("my_index", "address").AddQuery(e.NewQuery("query").AddQuery(e.NewQuery(e.RegExp).Add("postcode", "W[0-9].+"))).Get()
+	c.Search("my_index", "address").AddQuery(e.NewQuery("query").SetQuery(e.NewQuery(e.RegExp).Add("postcode", "W[0-9].+"))).Get()
 
 	// phrase query with prefix can be used for instant search (i.e. returning results to users as they are typing)
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewQuery(e.MatchPhrasePrefix).Add("brad", "johnie walker bl"))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewQuery(e.MatchPhrasePrefix).Add("brad", "johnie walker bl"))).Get()
 
 	// control the order of terms in phrase query
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewQuery(e.MatchPhrasePrefix).AddQuery(e.NewQuery("brand").Add("query", "johnie walker bl").Add(e.SLOP, 10)))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewQuery(e.MatchPhrasePrefix).SetQuery(e.NewQuery("brand").Add("query", "johnie walker bl").Add(e.SLOP, 10)))).Get()
 	// control how many terms the prefix can be expanded to
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewQuery(e.MatchPhrasePrefix).AddQuery(e.NewQuery("brand").Add("query", "johnie walker bl").Add(e.MaxExpansions, 50)))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewQuery(e.MatchPhrasePrefix).SetQuery(e.NewQuery("brand").Add("query", "johnie walker bl").Add(e.MaxExpansions, 50)))).Get()
 
 	// index-time search as you type
 	c.Index("my_index").Delete()
@@ -47,15 +47,15 @@ func chap16() {
 	c.Bulk("my_index", "my_type").AddOperation(e.NewOperation(1).Add("name", "Brown foxes")).AddOperation(e.NewOperation(2).Add("name", "Yellow furballs")).Post()
 
 	time.Sleep(1 * time.Second)
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().Add("name", "brown fo"))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().Add("name", "brown fo"))).Get()
 	// the validation api shine some lights to understand the search result
-	c.Validate("my_index", "my_type", true).AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().Add("name", "brown fo"))).Get()
+	c.Validate("my_index", "my_type", true).AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().Add("name", "brown fo"))).Get()
 	// we can overide at query time the autocomplete analyzer which has been used at index and query time
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().AddQuery(e.NewQuery("name").Add("query", "brown fo").Add("analyzer", "standard")))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().SetQuery(e.NewQuery("name").Add("query", "brown fo").Add("analyzer", "standard")))).Get()
 	// alternatively we can sepcify separate anlyzers for index and search time by a mapping
 	c.Mapping("my_index", "my_type").AddField("name", e.Dict{"type": "string", e.IndexAnalyzer: "autocomplete", e.SearchAnalyzer: "standard"}).Put()
 	// repeat the previous validate query
-	c.Validate("my_index", "my_type", true).AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().Add("name", "brown fo"))).Get()
+	c.Validate("my_index", "my_type", true).AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().Add("name", "brown fo"))).Get()
 
 	// use keyword tokenizer (that do nothing) as postcode needs to be analyzed
 	c.Index("my_index").Delete()
@@ -71,7 +71,7 @@ func chap16() {
 	c.Bulk("my_index", "my_type").AddOperation(e.NewOperation(1).Add("text", "Aussprachewörterbuch")).AddOperation(e.NewOperation(2).Add("text", "Militärgeschichte")).AddOperation(e.NewOperation(3).Add("text", "Wiebkopfseeadler")).AddOperation(e.NewOperation(4).Add("text", "Weltgesundheitsorganisation")).AddOperation(e.NewOperation(1).Add("text", "Rindfleischetikettierungsüberwachungsaufgabenübertragungsgesetz")).Post()
 	// search
 	time.Sleep(1 * time.Second)
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().Add("text", "Adler"))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().Add("text", "Adler"))).Get()
 	// use minimum_should_match to remove spurius results
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().AddQuery(e.NewQuery("text").Add("query", "Adler").Add(e.MinimumShouldMatch, "80%")))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().SetQuery(e.NewQuery("text").Add("query", "Adler").Add(e.MinimumShouldMatch, "80%")))).Get()
 }
@@ -23,7 +23,7 @@ func chap18() {
 	c.Insert("my_index", "blog").Document(1, e.Dict{"title": "I'm happy for this fox"}).Put()
 	c.Insert("my_index", "blog").Document(2, e.Dict{"title": "I'm not happy about my fox problem"}).Put()
 	t.Sleep(1 * t.Second)
-	c.Search("", "").AddQuery(e.NewQuery("query").AddQuery(e.NewMultiMatch().Add("type", "most_fields").Add("query", "not happy foxes").AddMultiple("fields", "title", "title.english"))).Pretty().Get()
+	c.Search("", "").AddQuery(e.NewQuery("query").SetQuery(e.NewMultiMatch().Add("type", "most_fields").Add("query", "not happy foxes").AddMultiple("fields", "title", "title.english"))).Pretty().Get()
 
 	// configuring language analyzers,
 	// e.g. use english as base analyzer then customize it
@@ -42,7 +42,7 @@ func chap18() {
 	t.Sleep(1 * t.Second)
 
 	// as there is an index for each language, we can specify a preference for particuar languages with 'indices_boost'
-	c.Search("blogs-*", "post").AddQuery(e.NewQuery("query").AddQuery(e.NewMultiMatch().Add("query", "deja vu").AddMultiple("fields", "title", "title.stemmed").Add("type", "most_fields"))).AddQuery(e.NewQuery(e.IndicesBoost).Add("blogs-en", 3).Add("blogs-fr", 2)).Pretty().Get()
+	c.Search("blogs-*", "post").AddQuery(e.NewQuery("query").SetQuery(e.NewMultiMatch().Add("query", "deja vu").AddMultiple("fields", "title", "title.stemmed").Add("type", "most_fields"))).AddQuery(e.NewQuery(e.IndicesBoost).Add("blogs-en", 3).Add("blogs-fr", 2)).Pretty().Get()
 	// it is also possible that a document contains multiple translations for a given field (e.g. title, title_fr, title_es)
 	c.Index("movies").Delete()
 	c.Index("movies").Mappings("movie", e.NewMapping().AddProperty("title", "type", "string").AddField("title_br", e.Dict{"type": "string", "analyzer": "brazilian"}).AddField("title_cz", e.Dict{"type": "string", "analyzer": "czech"}).AddField("title_en", e.Dict{"type": "string", "analyzer": "english"}).AddField("title_es", e.Dict{"type": "string", "analyzer": "spanish"})).Put()
@@ -52,5 +52,5 @@ func chap18() {
 	c.Index("movies").Delete()
 	c.Index("movies").AddAnalyzer(e.NewAnalyzer("filter").Add2("trigrams_filter", e.Dict{"type": "ngram", "min_gram": 3, "max_gram": 3})).AddAnalyzer(e.NewAnalyzer("analyzer").Add2("trigrams", e.Dict{"type": "custom", "tokenizer": "standard", "filter": []string{"lowercase", "trigrams_filter"}})).Mappings("movie", e.NewMapping().AddField("title", e.Dict{"type": "string", "fields": e.Dict{"de": e.Dict{"type": "string", "analyzer": "german"}, "en": e.Dict{"type": "string", "analyzer": "english"}, "fr": e.Dict{"type": "string", "analyzer": "french"}, "es": e.Dict{"type": "string", "analyzer": "spanish"}, "general": e.Dict{"type": "string", "analyzer": "trigrams"}}})).Put()
 	// e.g. of 'most_fields' search query
-	c.Search("movies", "movie").AddQuery(e.NewQuery("query").AddQuery(e.NewMultiMatch().Add("query", "club de la lucha").AddMultiple("fields", "title*^1.5", "title.general").Add("type", "most_fields").Add(e.MinimumShouldMatch, "75%"))).Get()
+	c.Search("movies", "movie").AddQuery(e.NewQuery("query").SetQuery(e.NewMultiMatch().Add("query", "club de la lucha").AddMultiple("fields", "title*^1.5", "title.general").Add("type", "most_fields").Add(e.MinimumShouldMatch, "75%"))).Get()
 }
@@ -35,10 +35,10 @@ func chap20() {
 	c.Insert("my_index", "my_type").Document(1, e.Dict{"title": "Esta loca!"}).Put()
 	c.Insert("my_index", "my_type").Document(2, e.Dict{"title": "Està loca!"}).Put()
 	t.Sleep(1 * t.Second)
-	c.Search("my_index", "").AddQuery(e.NewQuery("query").AddQuery(e.NewMultiMatch().Add("type", "most_fields").Add("query", "està loca").AddMultiple("fields", "title", "title.folded"))).Get()
+	c.Search("my_index", "").AddQuery(e.NewQuery("query").SetQuery(e.NewMultiMatch().Add("type", "most_fields").Add("query", "està loca").AddMultiple("fields", "title", "title.folded"))).Get()
 	// Explain the query for better understanding
 	t.Sleep(1 * t.Second)
-	c.Validate("my_index", "", true).AddQuery(e.NewQuery("query").AddQuery(e.NewMultiMatch().Add("type", "most_fields").Add("query", "està loca").AddMultiple("fields", "title", "title.folded"))).Get()
+	c.Validate("my_index", "", true).AddQuery(e.NewQuery("query").SetQuery(e.NewMultiMatch().Add("type", "most_fields").Add("query", "està loca").AddMultiple("fields", "title", "title.folded"))).Get()
 
 	// use icu_normalizer token filter to ensure that all tokens are in the same form
 	c.Index("my_index").Delete()
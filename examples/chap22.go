@@ -45,9 +45,9 @@ func chap22() {
 	c.Analyze("my_index").Analyzer("index_grams").Get("The quick and brwon fox")
 	c.Analyze("my_index").Analyzer("search_grams").Get("The quick and brwon fox")
 	// the index contains unigrams so it can be queried as usual
-	c.Search("my_index", "").AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().AddQuery(e.NewQuery("text").Add("query", "the quick and brown fox").Add(e.CutOffFrequency, 0.01)))).Get()
+	c.Search("my_index", "").AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().SetQuery(e.NewQuery("text").Add("query", "the quick and brown fox").Add(e.CutOffFrequency, 0.01)))).Get()
 	// bigram phrase queries
-	c.Search("my_index", "").AddQuery(e.NewQuery("query").AddQuery(e.NewMatchPhrase().AddQuery(e.NewQuery("text").Add("query", "The quick and brown fox").Add("analyzer", "search_grams")))).Get()
+	c.Search("my_index", "").AddQuery(e.NewQuery("query").SetQuery(e.NewMatchPhrase().SetQuery(e.NewQuery("text").Add("query", "The quick and brown fox").Add("analyzer", "search_grams")))).Get()
 	// Two word phrases become faster as it turns out to be a single term search
-	c.Search("my_index", "").AddQuery(e.NewQuery("query").AddQuery(e.NewMatchPhrase().AddQuery(e.NewQuery("text").Add("query", "The quick").Add("analyzer", "search_grams")))).Get()
+	c.Search("my_index", "").AddQuery(e.NewQuery("query").SetQuery(e.NewMatchPhrase().SetQuery(e.NewQuery("text").Add("query", "The quick").Add("analyzer", "search_grams")))).Get()
 }
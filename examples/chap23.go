@@ -19,13 +19,13 @@ func chap23() {
 	c.Index("my_index").AddAnalyzer(e.NewAnalyzer("filter").Add2("my_synonym_filter", e.Dict{e.Type: "synonym", e.Synonyms: []string{"usa,united states,u s a,united states of america"}})).AddAnalyzer(e.NewAnalyzer("analyzer").Add2("my_synonyms", e.Dict{e.Tokenizer: "standard", "filter": []string{"lowercase", "my_synonym_filter"}})).Put()
 	c.Analyze("my_index").Analyzer("my_synonyms").Get("The United States is wealthy")
 	// even more bizare stuff when using synonyms at query time
-	c.Validate("my_index", "", true).AddQuery(e.NewQuery("query").AddQuery(e.NewMatchPhrase().AddQuery(e.NewQuery("text").Add("query", "usa is wealthy").Add("analyzer", "my_synonyms")))).Get()
+	c.Validate("my_index", "", true).AddQuery(e.NewQuery("query").SetQuery(e.NewMatchPhrase().SetQuery(e.NewQuery("text").Add("query", "usa is wealthy").Add("analyzer", "my_synonyms")))).Get()
 	// to avoid this mess, use 'simple contraction' (which simply '=>' rule) for phrase queries
 	c.Index("my_index").Delete()
 	c.Index("my_index").AddAnalyzer(e.NewAnalyzer("filter").Add2("my_synonym_filter", e.Dict{e.Type: "synonym", e.Synonyms: []string{"united states,u s a,united states of america=>usa"}})).AddAnalyzer(e.NewAnalyzer("analyzer").Add2("my_synonyms", e.Dict{e.Tokenizer: "standard", "filter": []string{"lowercase", "my_synonym_filter"}})).Put()
 	// now test with same previous text
 	c.Analyze("my_index").Analyzer("my_synonyms").Get("The United States is wealthy")
-	c.Validate("my_index", "", true).AddQuery(e.NewQuery("query").AddQuery(e.NewMatchPhrase().AddQuery(e.NewQuery("text").Add("query", "usa is wealthy").Add("analyzer", "my_synonyms")))).Get()
+	c.Validate("my_index", "", true).AddQuery(e.NewQuery("query").SetQuery(e.NewMatchPhrase().SetQuery(e.NewQuery("text").Add("query", "usa is wealthy").Add("analyzer", "my_synonyms")))).Get()
 
 	// use char mapping filter to convert emoticons to their meaning and void to lose them as the standard tokenizer filter will remove them.
 	c.Index("my_index").Delete()
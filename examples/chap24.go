@@ -13,14 +13,14 @@ func chap24() {
 	// index some documents
 	c.Bulk("my_index", "my_type").AddOperation(e.NewOperation(1).Add("text", "Surprise me!")).AddOperation(e.NewOperation(2).Add("text", "That was surprising.")).AddOperation(e.NewOperation(3).Add("text", "I wasn't surprised.")).Post()
 	// fuzzy query
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewFuzzyQuery().Add("text", "surprize"))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewFuzzyQuery().Add("text", "surprize"))).Get()
 	// set the fuziness to limit number of matching documents
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewFuzzyQuery().Add("text", "surprize").Add(e.Fuzziness, 1))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewFuzzyQuery().Add("text", "surprize").Add(e.Fuzziness, 1))).Get()
 
 	// 'match' query with fuzziness: query text will be analyzed and each term will be fuzzified
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().AddQuery(e.NewQuery("text").Add("query", "SURPRIZE ME!").Add(e.Fuzziness, 1).Add(e.Operator, "and")))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().SetQuery(e.NewQuery("text").Add("query", "SURPRIZE ME!").Add(e.Fuzziness, 1).Add(e.Operator, "and")))).Get()
 	// 'multi_match' query with fuzziness
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewMultiMatch().Add("query", "SURPRIZE ME!").Add(e.Fuzziness, "AUTO").Add("fields", []string{"text", "title"}))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewMultiMatch().Add("query", "SURPRIZE ME!").Add(e.Fuzziness, "AUTO").Add("fields", []string{"text", "title"}))).Get()
 
 	// using phonetic plugin
 	c.Index("my_index").Delete()
@@ -34,5 +34,5 @@ func chap24() {
 	c.Insert("my_index", "my_type").Document(2, e.Dict{"name": "Jonnie Smythe"}).Put()
 	t.Sleep(1 * t.Second)
 	// now search: both document should be returned
-	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").AddQuery(e.NewMatch().AddQuery(e.NewQuery("name.phonetic").Add("query", "Jahnnie Smeeth").Add("operator", "and")))).Get()
+	c.Search("my_index", "my_type").AddQuery(e.NewQuery("query").SetQuery(e.NewMatch().SetQuery(e.NewQuery("name.phonetic").Add("query", "Jahnnie Smeeth").Add("operator", "and")))).Get()
 }
@@ -10,7 +10,7 @@ func chap31() {
 	c := &e.Elasticsearch{Addr: "localhost:9200"}
 
 	// Find all cars over $10000 and calculate the average price of these cars
-	c.Aggs("cars", "transactions").SetMetric(e.Count).AddQuery(e.NewQuery("filtered").AddQuery(e.NewQuery("filter").AddQuery(e.NewQuery("rage").AddQuery(e.NewQuery("price").Add("gte", "10000"))))).Add(e.NewBucket("single_avg_price").AddMetric(e.Avg, e.Field, "price")).Get()
+	c.Aggs("cars", "transactions").SetMetric(e.Count).AddQuery(e.NewQuery("filtered").SetQuery(e.NewQuery("filter").SetQuery(e.RangeQuery("price").Gte(10000)))).Add(e.NewBucket("single_avg_price").AddMetric(e.Avg, e.Field, "price")).Get()
 
 	// filtering aggregation results
 	c.Aggs("cars", "transactions").SetMetric(e.Count).AddQuery(e.NewMatch().Add("make", "ford")).Add(e.NewBucket("recent_sales").AddDict(e.FilterBucket, e.Dict{"range": e.Dict{"sold": e.Dict{"from": "now-1M"}}}).AddBucket(e.NewBucket("average_price").AddMetric(e.Avg, e.Field, "price"))).Get()
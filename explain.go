@@ -0,0 +1,156 @@
+package elastic
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ExplainDoc a request of the Explain API, it returns how a document is scored by a query (or why it does not match)
+type ExplainDoc struct {
+	client *Elasticsearch
+	parser *ExplainDocResultParser
+	url    string
+	params map[string]string
+	query  Dict
+}
+
+// Explain creates an Explaination request, that will return explanation for why a document is returned by the query
+func (client *Elasticsearch) Explain(index, class string, id int64) *ExplainDoc {
+	url := client.request(index, class, id, EXPLAIN)
+	return newExplainDoc(client, url)
+}
+
+// newExplainDoc creates a new Explain API request
+func newExplainDoc(client *Elasticsearch, url string) *ExplainDoc {
+	return &ExplainDoc{
+		client: client,
+		parser: &ExplainDocResultParser{},
+		url:    url,
+		params: make(map[string]string),
+		query:  make(Dict),
+	}
+}
+
+// AddParam adds a url parameter/value, e.g. routing
+func (explain *ExplainDoc) AddParam(name, value string) *ExplainDoc {
+	explain.params[name] = value
+	return explain
+}
+
+// Pretty pretiffies the response result
+func (explain *ExplainDoc) Pretty() *ExplainDoc {
+	explain.AddParam("pretty", "")
+	return explain
+}
+
+// AddQuery adds a query to this request
+func (explain *ExplainDoc) AddQuery(query Query) *ExplainDoc {
+	explain.query[query.Name()] = query.KV()
+	return explain
+}
+
+// Add adds a query argument/value
+func (explain *ExplainDoc) Add(argument string, value interface{}) *ExplainDoc {
+	explain.query[argument] = value
+	return explain
+}
+
+// String returns a string representation of the body of this request
+func (explain *ExplainDoc) String() string {
+	return String(explain.query)
+}
+
+// urlString constructs the url of this request
+func (explain *ExplainDoc) urlString() string {
+	return urlString(explain.url, explain.params)
+}
+
+// Get submits this request and logs the result
+// GET /:index/:type/:id/_explain
+func (explain *ExplainDoc) Get() {
+	explain.client.Execute("GET", explain.urlString(), explain.String(), explain.parser)
+}
+
+// Do submits this request and returns the explanation of the score of the document
+// GET /:index/:type/:id/_explain
+func (explain *ExplainDoc) Do() (*ExplainDocResult, error) {
+	result, err := explain.client.Execute("GET", explain.urlString(), explain.String(), explain.parser)
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case ExplainDocResult:
+		return &res, nil
+	case Failure:
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
+}
+
+// TopClause returns the query clause that contributed the most to the score of the document, nil if it does not match
+func (result *ExplainDocResult) TopClause() *ScoreExplanation {
+	if !result.Matched || result.Explanation == nil {
+		return nil
+	}
+	return result.Explanation.TopClause()
+}
+
+// String returns the explanation formatted like Lucene, each sub score is indented under the score it's part of, e.g.
+//
+//	1.2 = sum of:
+//	  1.2 = weight(title:fox in 0) [PerFieldSimilarity], result of:
+//	    1.2 = score(doc=0,freq=1.0), product of:
+func (explanation *ScoreExplanation) String() string {
+	buffer := &bytes.Buffer{}
+	explanation.write(buffer, 0)
+	return buffer.String()
+}
+
+// write writes this explanation and its details at the given depth
+func (explanation *ScoreExplanation) write(buffer *bytes.Buffer, depth int) {
+	value := strconv.FormatFloat(explanation.Value, 'g', -1, 32)
+	fmt.Fprintf(buffer, "%s%s = %s\n", strings.Repeat("  ", depth), value, explanation.Description)
+	for i := range explanation.Details {
+		explanation.Details[i].write(buffer, depth+1)
+	}
+}
+
+// combines whether this explanation combines the scores of query clauses (e.g. a bool or dis_max query)
+func (explanation *ScoreExplanation) combines() bool {
+	return len(explanation.Details) > 0 &&
+		(strings.HasPrefix(explanation.Description, "sum of") || strings.HasPrefix(explanation.Description, "max of") ||
+			strings.HasPrefix(explanation.Description, "max plus"))
+}
+
+// TopClause returns the query clause that contributed the most to this score.
+// Combinations of clauses (e.g. 'sum of:' of bool queries, 'max of:' of dis_max queries) are walked down to the clause with the highest score.
+func (explanation *ScoreExplanation) TopClause() *ScoreExplanation {
+	top := explanation
+	for top.combines() {
+		next := &top.Details[0]
+		for i := range top.Details {
+			if top.Details[i].Value > next.Value {
+				next = &top.Details[i]
+			}
+		}
+		top = next
+	}
+	return top
+}
+
+// Clause returns the query clause of this score, e.g. title:fox for 'weight(title:fox in 0) [PerFieldSimilarity], result of:'.
+// The description is returned when it's not the score of a clause.
+func (explanation *ScoreExplanation) Clause() string {
+	description := explanation.Description
+	if !strings.HasPrefix(description, "weight(") {
+		return description
+	}
+	end := strings.LastIndex(description, " in ")
+	if end < 0 {
+		return description
+	}
+	return description[len("weight("):end]
+}
@@ -0,0 +1,40 @@
+package elastic
+
+import (
+	"strings"
+	"testing"
+)
+
+// explainResponse an explain response of a bool query with two should clauses
+const explainResponse = `{"_index":"blog","_type":"post","_id":"1","matched":true,"explanation":{"value":1.5,"description":"sum of:","details":[` +
+	`{"value":0.5,"description":"weight(title:fox in 0) [PerFieldSimilarity], result of:","details":[{"value":0.5,"description":"fieldWeight in 0, product of:","details":[]}]},` +
+	`{"value":1.0,"description":"max of:","details":[{"value":1.0,"description":"weight(body:fox in 0) [PerFieldSimilarity], result of:","details":[]},{"value":0.25,"description":"weight(tags:fox in 0) [PerFieldSimilarity], result of:","details":[]}]}]}}`
+
+// test for the Explain API
+func TestExplain(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(map[string]string{"GET /blog/post/1/_explain": explainResponse}, &requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	result, err := client.Explain("blog", "post", 1).AddQuery(NewQuery("query").SetQuery(MatchQuery("title", "fox"))).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Matched || result.Explanation.Value != 1.5 {
+		t.Error("Unexpected result", result)
+	}
+	expected := []string{
+		"1.5 = sum of:",
+		"  0.5 = weight(title:fox in 0) [PerFieldSimilarity], result of:",
+		"    0.5 = fieldWeight in 0, product of:",
+		"  1 = max of:",
+		"    1 = weight(body:fox in 0) [PerFieldSimilarity], result of:",
+		"    0.25 = weight(tags:fox in 0) [PerFieldSimilarity], result of:",
+	}
+	equals(t, strings.Split(strings.TrimSpace(result.Explanation.String()), "\n"), expected)
+	equals(t, []string{result.TopClause().Clause()}, []string{"body:fox"})
+	// a failure is returned as an error
+	if _, err := client.Explain("blog", "post", 2).Do(); err == nil {
+		t.Error("Should fail")
+	}
+}
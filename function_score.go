@@ -0,0 +1,432 @@
+package elastic
+
+import (
+	"fmt"
+)
+
+// Decay functions of a function_score query
+const (
+	// Gauss a decay function following a normal distribution
+	Gauss = "gauss"
+	// Exp a decay function following an exponential distribution
+	Exp = "exp"
+	// Linear a decay function decreasing linearly to zero
+	Linear = "linear"
+	// Origin a parameter of decay functions defining the central point from which distances are computed
+	Origin = "origin"
+	// Scale a parameter of decay functions defining the distance from origin + offset at which the score equals 'decay'
+	Scale = "scale"
+	// Offset a parameter of decay functions defining the distance from origin within which the score is not decayed
+	Offset = "offset"
+	// Decay a parameter of decay functions defining the score at 'scale' distance (0.5 by default)
+	Decay = "decay"
+	// MinScore a parameter of function_score query excluding documents with a lower score
+	MinScore = "min_score"
+	// Functions a parameter of function_score query holding the list of scoring functions
+	Functions = "functions"
+)
+
+// FieldValueModifier a modifier applied to the field value of a 'field_value_factor' function
+type FieldValueModifier string
+
+// Modifiers of a 'field_value_factor' function
+const (
+	// ModifierNone the field value is used as is (default)
+	ModifierNone FieldValueModifier = "none"
+	// ModifierLog the common logarithm of the field value, values between 0 and 1 give a negative score
+	ModifierLog FieldValueModifier = "log"
+	// ModifierLog1p the common logarithm of the field value plus one
+	ModifierLog1p FieldValueModifier = "log1p"
+	// ModifierLog2p the common logarithm of the field value plus two
+	ModifierLog2p FieldValueModifier = "log2p"
+	// ModifierLn the natural logarithm of the field value, values between 0 and 1 give a negative score
+	ModifierLn FieldValueModifier = "ln"
+	// ModifierLn1p the natural logarithm of the field value plus one
+	ModifierLn1p FieldValueModifier = "ln1p"
+	// ModifierLn2p the natural logarithm of the field value plus two
+	ModifierLn2p FieldValueModifier = "ln2p"
+	// ModifierSquare the square of the field value
+	ModifierSquare FieldValueModifier = "square"
+	// ModifierSqrt the square root of the field value
+	ModifierSqrt FieldValueModifier = "sqrt"
+	// ModifierReciprocal the reciprocal of the field value (i.e. 1/x)
+	ModifierReciprocal FieldValueModifier = "reciprocal"
+)
+
+// FunctionScoreMode defines how the scores of the functions of a function_score query are combined
+type FunctionScoreMode string
+
+// Score modes of a function_score query
+const (
+	// ScoreModeMultiply the scores are multiplied (default)
+	ScoreModeMultiply FunctionScoreMode = "multiply"
+	// ScoreModeSum the scores are summed
+	ScoreModeSum FunctionScoreMode = "sum"
+	// ScoreModeAvg the scores are averaged
+	ScoreModeAvg FunctionScoreMode = "avg"
+	// ScoreModeFirst the score of the first function with a matching filter is used
+	ScoreModeFirst FunctionScoreMode = "first"
+	// ScoreModeMax the maximum score is used
+	ScoreModeMax FunctionScoreMode = "max"
+	// ScoreModeMin the minimum score is used
+	ScoreModeMin FunctionScoreMode = "min"
+)
+
+// FunctionBoostMode defines how the combined score of the functions of a function_score query is combined with the query score
+type FunctionBoostMode string
+
+// Boost modes of a function_score query
+const (
+	// BoostModeMultiply the query score and the function score are multiplied (default)
+	BoostModeMultiply FunctionBoostMode = "multiply"
+	// BoostModeReplace only the function score is used, the query score is ignored
+	BoostModeReplace FunctionBoostMode = "replace"
+	// BoostModeSum the query score and the function score are summed
+	BoostModeSum FunctionBoostMode = "sum"
+	// BoostModeAvg the query score and the function score are averaged
+	BoostModeAvg FunctionBoostMode = "avg"
+	// BoostModeMax the maximum of the query score and the function score is used
+	BoostModeMax FunctionBoostMode = "max"
+	// BoostModeMin the minimum of the query score and the function score is used
+	BoostModeMin FunctionBoostMode = "min"
+)
+
+var (
+	// scoreModes the accepted values of 'score_mode' in a function_score query
+	scoreModes = []string{
+		string(ScoreModeMultiply), string(ScoreModeSum), string(ScoreModeAvg), string(ScoreModeFirst), string(ScoreModeMax), string(ScoreModeMin),
+	}
+	// boostModes the accepted values of 'boost_mode' in a function_score query
+	boostModes = []string{
+		string(BoostModeMultiply), string(BoostModeReplace), string(BoostModeSum), string(BoostModeAvg), string(BoostModeMax), string(BoostModeMin),
+	}
+	// modifiers the accepted values of 'modifier' in a field_value_factor function
+	modifiers = []string{
+		string(ModifierNone), string(ModifierLog), string(ModifierLog1p), string(ModifierLog2p), string(ModifierLn), string(ModifierLn1p),
+		string(ModifierLn2p), string(ModifierSquare), string(ModifierSqrt), string(ModifierReciprocal),
+	}
+)
+
+// ScoreFunction an interface of the scoring functions of a function_score query
+type ScoreFunction interface {
+	// Dict returns the entry of the function in the 'functions' list
+	Dict() Dict
+}
+
+// functionOptions the options shared by all scoring functions
+type functionOptions struct {
+	filter Query
+	weight interface{}
+}
+
+// dict returns an entry of the 'functions' list with the given function body and the shared options
+func (options *functionOptions) dict(name string, body interface{}) Dict {
+	dict := make(Dict)
+	if name != "" {
+		dict[name] = body
+	}
+	if options.filter != nil {
+		dict[FILTER] = Dict{options.filter.Name(): options.filter.KV()}
+	}
+	if options.weight != nil {
+		dict[Weight] = options.weight
+	}
+	return dict
+}
+
+// FunctionScoreQuery a structure representing the 'function_score' query
+type FunctionScoreQuery struct {
+	query     Query
+	functions []ScoreFunction
+	kv        Dict
+}
+
+// NewFunctionScoreQuery creates a new 'function_score' query that modifies the score of the documents matching the given query
+func NewFunctionScoreQuery(query Query) *FunctionScoreQuery {
+	return &FunctionScoreQuery{query: query, kv: make(Dict)}
+}
+
+// Name returns the name of this query
+func (fs *FunctionScoreQuery) Name() string {
+	return FunctionScore
+}
+
+// KV returns the body of this query as a dictionary
+func (fs *FunctionScoreQuery) KV() Dict {
+	dict := make(Dict)
+	for k, v := range fs.kv {
+		dict[k] = v
+	}
+	if fs.query != nil {
+		dict["query"] = clause{query: fs.query, named: true}
+	}
+	if len(fs.functions) > 0 {
+		functions := []Dict{}
+		for _, function := range fs.functions {
+			functions = append(functions, function.Dict())
+		}
+		dict[Functions] = functions
+	}
+	return dict
+}
+
+// String returns a string representation of this query
+func (fs *FunctionScoreQuery) String() string {
+	return String(Dict{fs.Name(): fs.KV()})
+}
+
+// AddFunction appends scoring functions
+func (fs *FunctionScoreQuery) AddFunction(functions ...ScoreFunction) *FunctionScoreQuery {
+	fs.functions = append(fs.functions, functions...)
+	return fs
+}
+
+// ScoreMode sets how the scores of the functions are combined (i.e. multiply, sum, avg, first, max, min)
+func (fs *FunctionScoreQuery) ScoreMode(mode FunctionScoreMode) *FunctionScoreQuery {
+	fs.kv[ScoreMode] = mode
+	return fs
+}
+
+// BoostMode sets how the combined score of the functions is combined with the query score (i.e. multiply, replace, sum, avg, max, min)
+func (fs *FunctionScoreQuery) BoostMode(mode FunctionBoostMode) *FunctionScoreQuery {
+	fs.kv[BoostMode] = mode
+	return fs
+}
+
+// MaxBoost caps the score calculated by the functions
+func (fs *FunctionScoreQuery) MaxBoost(max float32) *FunctionScoreQuery {
+	fs.kv[MaxBoost] = max
+	return fs
+}
+
+// MinScore excludes documents with a lower final score
+func (fs *FunctionScoreQuery) MinScore(min float32) *FunctionScoreQuery {
+	fs.kv[MinScore] = min
+	return fs
+}
+
+// Boost sets the boost of this query
+func (fs *FunctionScoreQuery) Boost(boost float32) *FunctionScoreQuery {
+	fs.kv[Boost] = boost
+	return fs
+}
+
+// Validate checks the values of 'score_mode', 'boost_mode' and of the modifiers of the functions.
+// Lint reports the same errors, a search request enforcing lint is not sent when they are found.
+func (fs *FunctionScoreQuery) Validate() error {
+	if mode, ok := fs.kv[ScoreMode].(FunctionScoreMode); ok && !containsString(scoreModes, string(mode)) {
+		return fmt.Errorf("invalid %s '%s', possible values: %v", ScoreMode, mode, scoreModes)
+	}
+	if mode, ok := fs.kv[BoostMode].(FunctionBoostMode); ok && !containsString(boostModes, string(mode)) {
+		return fmt.Errorf("invalid %s '%s', possible values: %v", BoostMode, mode, boostModes)
+	}
+	for _, function := range fs.functions {
+		if factor, ok := function.(*FieldValueFactorFunction); ok && factor.kv[Modifer] != nil {
+			if modifier := factor.kv[Modifer].(FieldValueModifier); !containsString(modifiers, string(modifier)) {
+				return fmt.Errorf("invalid %s '%s', possible values: %v", Modifer, modifier, modifiers)
+			}
+		}
+	}
+	return nil
+}
+
+// containsString checks if the given value is in the list
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// DecayFunction a structure representing a decay function (i.e. gauss, exp, linear)
+type DecayFunction struct {
+	functionOptions
+	name  string
+	field string
+	kv    Dict
+}
+
+// newDecayFunction creates a decay function on the given field
+func newDecayFunction(name, field string, origin, scale interface{}) *DecayFunction {
+	return &DecayFunction{name: name, field: field, kv: Dict{Origin: origin, Scale: scale}}
+}
+
+// NewGaussFunction creates a 'gauss' decay function, origin and scale can be numbers, dates (e.g. now, 10d) or geo points (e.g. 2km)
+func NewGaussFunction(field string, origin, scale interface{}) *DecayFunction {
+	return newDecayFunction(Gauss, field, origin, scale)
+}
+
+// NewExpFunction creates an 'exp' decay function, origin and scale can be numbers, dates (e.g. now, 10d) or geo points (e.g. 2km)
+func NewExpFunction(field string, origin, scale interface{}) *DecayFunction {
+	return newDecayFunction(Exp, field, origin, scale)
+}
+
+// NewLinearFunction creates a 'linear' decay function, origin and scale can be numbers, dates (e.g. now, 10d) or geo points (e.g. 2km)
+func NewLinearFunction(field string, origin, scale interface{}) *DecayFunction {
+	return newDecayFunction(Linear, field, origin, scale)
+}
+
+// Offset sets the distance from origin within which the score is not decayed
+func (decay *DecayFunction) Offset(offset interface{}) *DecayFunction {
+	decay.kv[Offset] = offset
+	return decay
+}
+
+// Decay sets the score at 'scale' distance from origin + offset
+func (decay *DecayFunction) Decay(value float32) *DecayFunction {
+	decay.kv[Decay] = value
+	return decay
+}
+
+// Filter applies this function only on the documents matching the given query
+func (decay *DecayFunction) Filter(query Query) *DecayFunction {
+	decay.filter = query
+	return decay
+}
+
+// Weight multiplies the score of this function
+func (decay *DecayFunction) Weight(weight float32) *DecayFunction {
+	decay.weight = weight
+	return decay
+}
+
+// Dict returns the entry of this function in the 'functions' list
+func (decay *DecayFunction) Dict() Dict {
+	return decay.dict(decay.name, Dict{decay.field: decay.kv})
+}
+
+// FieldValueFactorFunction a structure representing the 'field_value_factor' function
+type FieldValueFactorFunction struct {
+	functionOptions
+	kv Dict
+}
+
+// NewFieldValueFactorFunction creates a 'field_value_factor' function using the value of the given field (e.g. votes)
+func NewFieldValueFactorFunction(field string) *FieldValueFactorFunction {
+	return &FieldValueFactorFunction{kv: Dict{Field: field}}
+}
+
+// Factor sets the value multiplying the field value
+func (factor *FieldValueFactorFunction) Factor(value float32) *FieldValueFactorFunction {
+	factor.kv[Factor] = value
+	return factor
+}
+
+// Modifier sets the modifier applied to the field value (e.g. log1p)
+func (factor *FieldValueFactorFunction) Modifier(modifier FieldValueModifier) *FieldValueFactorFunction {
+	factor.kv[Modifer] = modifier
+	return factor
+}
+
+// Missing sets the value used for documents missing the field
+func (factor *FieldValueFactorFunction) Missing(value float32) *FieldValueFactorFunction {
+	factor.kv[Missing] = value
+	return factor
+}
+
+// Filter applies this function only on the documents matching the given query
+func (factor *FieldValueFactorFunction) Filter(query Query) *FieldValueFactorFunction {
+	factor.filter = query
+	return factor
+}
+
+// Weight multiplies the score of this function
+func (factor *FieldValueFactorFunction) Weight(weight float32) *FieldValueFactorFunction {
+	factor.weight = weight
+	return factor
+}
+
+// Dict returns the entry of this function in the 'functions' list
+func (factor *FieldValueFactorFunction) Dict() Dict {
+	return factor.dict(FieldValueFactor, factor.kv)
+}
+
+// RandomScoreFunction a structure representing the 'random_score' function
+type RandomScoreFunction struct {
+	functionOptions
+	kv Dict
+}
+
+// NewRandomScoreFunction creates a 'random_score' function
+func NewRandomScoreFunction() *RandomScoreFunction {
+	return &RandomScoreFunction{kv: make(Dict)}
+}
+
+// Seed sets the seed (e.g. a user session id) and the field used to get reproducible scores
+func (random *RandomScoreFunction) Seed(seed interface{}, field string) *RandomScoreFunction {
+	random.kv[Seed] = seed
+	random.kv[Field] = field
+	return random
+}
+
+// Filter applies this function only on the documents matching the given query
+func (random *RandomScoreFunction) Filter(query Query) *RandomScoreFunction {
+	random.filter = query
+	return random
+}
+
+// Weight multiplies the score of this function
+func (random *RandomScoreFunction) Weight(weight float32) *RandomScoreFunction {
+	random.weight = weight
+	return random
+}
+
+// Dict returns the entry of this function in the 'functions' list
+func (random *RandomScoreFunction) Dict() Dict {
+	return random.dict(RandomScore, random.kv)
+}
+
+// ScriptScoreFunction a structure representing the 'script_score' function
+type ScriptScoreFunction struct {
+	functionOptions
+	script Dict
+}
+
+// NewScriptScoreFunction creates a 'script_score' function calculating the score with the given painless script
+func NewScriptScoreFunction(source string, params Dict) *ScriptScoreFunction {
+	script := Dict{"lang": Painless, "source": source}
+	if len(params) > 0 {
+		script["params"] = params
+	}
+	return &ScriptScoreFunction{script: script}
+}
+
+// Filter applies this function only on the documents matching the given query
+func (script *ScriptScoreFunction) Filter(query Query) *ScriptScoreFunction {
+	script.filter = query
+	return script
+}
+
+// Weight multiplies the score of this function
+func (script *ScriptScoreFunction) Weight(weight float32) *ScriptScoreFunction {
+	script.weight = weight
+	return script
+}
+
+// Dict returns the entry of this function in the 'functions' list
+func (script *ScriptScoreFunction) Dict() Dict {
+	return script.dict(ScriptScore, Dict{"script": script.script})
+}
+
+// WeightFunction a structure representing the 'weight' function, it gives a constant score to the documents matching its filter
+type WeightFunction struct {
+	functionOptions
+}
+
+// NewWeightFunction creates a 'weight' function
+func NewWeightFunction(weight float32) *WeightFunction {
+	return &WeightFunction{functionOptions{weight: weight}}
+}
+
+// Filter applies this function only on the documents matching the given query
+func (weight *WeightFunction) Filter(query Query) *WeightFunction {
+	weight.filter = query
+	return weight
+}
+
+// Dict returns the entry of this function in the 'functions' list
+func (weight *WeightFunction) Dict() Dict {
+	return weight.dict("", nil)
+}
@@ -0,0 +1,56 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for function_score queries
+func TestFunctionScoreQuery(t *testing.T) {
+	actual := []string{
+		NewFunctionScoreQuery(MatchQuery("title", "popularity")).AddFunction(NewFieldValueFactorFunction("votes").Modifier(ModifierLog1p).Factor(0.5).Missing(1)).BoostMode("sum").MaxBoost(1.5).String(),
+		NewFunctionScoreQuery(TermQuery("city", "Barcelona")).AddFunction(NewWeightFunction(1).Filter(TermQuery("features", "wifi")), NewWeightFunction(2).Filter(TermQuery("features", "pool"))).ScoreMode("sum").String(),
+		NewFunctionScoreQuery(nil).AddFunction(NewGaussFunction("location", NewGeoPoint(51.5, 0.12), "3km").Offset("2km"), NewExpFunction("price", 50, 20).Offset(50).Decay(0.3).Weight(2)).String(),
+		NewFunctionScoreQuery(MatchAllQuery()).AddFunction(NewRandomScoreFunction().Seed("session-id", "_seq_no"), NewScriptScoreFunction("params.factor * doc['margin'].value", Dict{"factor": 1.2}).Filter(ExistsQuery("margin"))).MinScore(0.1).String(),
+	}
+	expected := []string{
+		`{"function_score":{"boost_mode":"sum","functions":[{"field_value_factor":{"factor":0.5,"field":"votes","missing":1,"modifier":"log1p"}}],"max_boost":1.5,"query":{"match":{"title":{"query":"popularity"}}}}}`,
+		`{"function_score":{"functions":[{"filter":{"term":{"features":{"value":"wifi"}}},"weight":1},{"filter":{"term":{"features":{"value":"pool"}}},"weight":2}],"query":{"term":{"city":{"value":"Barcelona"}}},"score_mode":"sum"}}`,
+		`{"function_score":{"functions":[{"gauss":{"location":{"offset":"2km","origin":{"lat":51.5,"lon":0.12},"scale":"3km"}}},{"exp":{"price":{"decay":0.3,"offset":50,"origin":50,"scale":20}},"weight":2}]}}`,
+		`{"function_score":{"functions":[{"random_score":{"field":"_seq_no","seed":"session-id"}},{"filter":{"exists":{"field":"margin"}},"script_score":{"script":{"lang":"painless","params":{"factor":1.2},"source":"params.factor * doc['margin'].value"}}}],"min_score":0.1,"query":{"match_all":{}}}}`,
+	}
+	equals(t, actual, expected)
+}
+
+// test for validation of function_score queries
+func TestFunctionScoreValidate(t *testing.T) {
+	valid := NewFunctionScoreQuery(nil).AddFunction(NewFieldValueFactorFunction("votes").Modifier(ModifierSqrt)).ScoreMode(ScoreModeMax).BoostMode(BoostModeReplace)
+	if err := valid.Validate(); err != nil {
+		t.Error("Should be valid", err)
+	}
+	invalid := []*FunctionScoreQuery{
+		NewFunctionScoreQuery(nil).ScoreMode("replace"),
+		NewFunctionScoreQuery(nil).BoostMode("first"),
+		NewFunctionScoreQuery(nil).AddFunction(NewFieldValueFactorFunction("votes").Modifier("log10")),
+	}
+	paths := []string{"query.function_score.score_mode", "query.function_score.boost_mode", "query.function_score.functions[0].field_value_factor.modifier"}
+	for i, query := range invalid {
+		if err := query.Validate(); err == nil {
+			t.Error("Should be invalid", query)
+		}
+		// the same errors are reported by Lint, and block a search request enforcing lint
+		search := emptySearch().SetQuery(query)
+		if issues := Lint(search); len(issues) != 1 || issues[0].Path != paths[i] || issues[0].Severity != SeverityError {
+			t.Error("Should report the invalid value", query, issues)
+		}
+		if _, err := search.EnforceLint().Do(); err == nil {
+			t.Error("Should not send an invalid function_score", query)
+		}
+	}
+	factor := NewFunctionScore().SetQuery(NewQuery(FieldValueFactor).Add(Field, "votes").Add(Modifer, "log10"))
+	if issues := Lint(emptySearch().SetQuery(factor)); len(issues) != 1 || issues[0].Path != "query.function_score.field_value_factor.modifier" {
+		t.Error("Should report the invalid modifier of a field_value_factor", issues)
+	}
+	if issues := Lint(emptySearch().SetQuery(valid)); len(issues) != 0 {
+		t.Error("Should not report a valid function_score", issues)
+	}
+}
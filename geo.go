@@ -0,0 +1,310 @@
+package elastic
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Geo query names
+const (
+	// GeoDistanceQueryName a query name. It matches documents located within a distance of a geo point.
+	GeoDistanceQueryName = "geo_distance"
+	// GeoBoundingBox a query name. It matches documents located within a rectangle.
+	GeoBoundingBox = "geo_bounding_box"
+	// GeoPolygon a query name. It matches documents located within a polygon.
+	GeoPolygon = "geo_polygon"
+	// GeoShape a query name. It matches documents with a geo shape intersecting (or within, disjoint, contains) a shape.
+	GeoShape = "geo_shape"
+	// Distance a parameter of 'geo_distance' query defining the radius of the circle centered on the geo point (e.g. 12km)
+	Distance = "distance"
+	// Relation a parameter of 'geo_shape' query. Possible values: intersects (default), disjoint, within, contains.
+	Relation = "relation"
+)
+
+// GeoPoint a structure representing a geographic location.
+// It is rendered as an object with lat/lon, or as a geohash when only a geohash is given, or as WKT when requested with AsWKT.
+type GeoPoint struct {
+	Lat     float64
+	Lon     float64
+	Geohash string
+	wkt     bool
+}
+
+// NewGeoPoint creates a new geo point from a latitude and longitude
+func NewGeoPoint(lat, lon float64) GeoPoint {
+	return GeoPoint{Lat: lat, Lon: lon}
+}
+
+// NewGeohashPoint creates a new geo point from a geohash (e.g. drm3btev3e86)
+func NewGeohashPoint(geohash string) GeoPoint {
+	return GeoPoint{Geohash: geohash}
+}
+
+// AsWKT returns a copy of this geo point that is rendered in Well-Known Text, e.g. POINT (-71.34 41.12)
+func (point GeoPoint) AsWKT() GeoPoint {
+	point.wkt = true
+	return point
+}
+
+// WKT returns the Well-Known Text representation of this geo point
+func (point GeoPoint) WKT() string {
+	return fmt.Sprintf("POINT (%s %s)", formatCoordinate(point.Lon), formatCoordinate(point.Lat))
+}
+
+// MarshalJSON returns the JSON representation of this geo point
+func (point GeoPoint) MarshalJSON() ([]byte, error) {
+	if point.wkt {
+		return json.Marshal(point.WKT())
+	}
+	if point.Geohash != "" && point.Lat == 0 && point.Lon == 0 {
+		return json.Marshal(point.Geohash)
+	}
+	return json.Marshal(Dict{"lat": point.Lat, "lon": point.Lon})
+}
+
+// UnmarshalJSON decodes a geo point given as an object, an array [lon, lat], a string "lat,lon", a WKT point or a geohash
+func (point *GeoPoint) UnmarshalJSON(data []byte) error {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		lat, ok1 := v["lat"].(float64)
+		lon, ok2 := v["lon"].(float64)
+		if !ok1 || !ok2 {
+			return fmt.Errorf("invalid geo point %s", string(data))
+		}
+		*point = NewGeoPoint(lat, lon)
+	case []interface{}:
+		if len(v) != 2 {
+			return fmt.Errorf("invalid geo point %s", string(data))
+		}
+		lon, ok1 := v[0].(float64)
+		lat, ok2 := v[1].(float64)
+		if !ok1 || !ok2 {
+			return fmt.Errorf("invalid geo point %s", string(data))
+		}
+		*point = NewGeoPoint(lat, lon)
+	case string:
+		return point.parse(v)
+	default:
+		return fmt.Errorf("invalid geo point %s", string(data))
+	}
+	return nil
+}
+
+// parse decodes a geo point given as a string "lat,lon", a WKT point or a geohash, other strings are rejected
+func (point *GeoPoint) parse(value string) error {
+	if strings.HasPrefix(strings.ToUpper(value), "POINT") {
+		var lat, lon float64
+		if _, err := fmt.Sscanf(strings.ToUpper(value), "POINT (%g %g)", &lon, &lat); err != nil {
+			return fmt.Errorf("invalid geo point %s: %v", value, err)
+		}
+		*point = NewGeoPoint(lat, lon).AsWKT()
+		return nil
+	}
+	if parts := strings.Split(value, ","); len(parts) == 2 {
+		lat, err1 := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+		lon, err2 := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+		if err1 != nil || err2 != nil {
+			return fmt.Errorf("invalid geo point %s", value)
+		}
+		*point = NewGeoPoint(lat, lon)
+		return nil
+	}
+	if !isGeohash(value) {
+		return fmt.Errorf("invalid geo point %s", value)
+	}
+	*point = NewGeohashPoint(value)
+	return nil
+}
+
+// geohashAlphabet the base 32 characters of geohashes
+const geohashAlphabet = "0123456789bcdefghjkmnpqrstuvwxyz"
+
+// isGeohash checks whether the given value is a geohash, i.e. 1 to 12 characters of the geohash alphabet
+func isGeohash(value string) bool {
+	if len(value) == 0 || len(value) > 12 {
+		return false
+	}
+	for _, c := range strings.ToLower(value) {
+		if !strings.ContainsRune(geohashAlphabet, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// coordinates returns the GeoJSON coordinates [lon, lat] of this geo point
+func (point GeoPoint) coordinates() []float64 {
+	return []float64{point.Lon, point.Lat}
+}
+
+// formatCoordinate formats a coordinate with the minimum number of digits
+func formatCoordinate(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// Shape a structure representing a GeoJSON shape (e.g. point, polygon, envelope)
+type Shape struct {
+	Type        string      `json:"type"`
+	Coordinates interface{} `json:"coordinates,omitempty"`
+	Radius      string      `json:"radius,omitempty"`
+	Geometries  []*Shape    `json:"geometries,omitempty"`
+}
+
+// NewPointShape creates a GeoJSON point
+func NewPointShape(point GeoPoint) *Shape {
+	return &Shape{Type: "point", Coordinates: point.coordinates()}
+}
+
+// NewLineStringShape creates a GeoJSON line string
+func NewLineStringShape(points ...GeoPoint) *Shape {
+	return &Shape{Type: "linestring", Coordinates: lineCoordinates(points)}
+}
+
+// NewPolygonShape creates a GeoJSON polygon, the first ring is the outer boundary and the following ones are holes.
+// Each ring should be closed, i.e. its first and last points are the same.
+func NewPolygonShape(rings ...[]GeoPoint) *Shape {
+	coordinates := [][][]float64{}
+	for _, ring := range rings {
+		coordinates = append(coordinates, lineCoordinates(ring))
+	}
+	return &Shape{Type: "polygon", Coordinates: coordinates}
+}
+
+// NewEnvelopeShape creates a rectangle from its top left and bottom right corners
+func NewEnvelopeShape(topLeft, bottomRight GeoPoint) *Shape {
+	return &Shape{Type: "envelope", Coordinates: [][]float64{topLeft.coordinates(), bottomRight.coordinates()}}
+}
+
+// NewCircleShape creates a circle from its center and radius (e.g. 100m)
+func NewCircleShape(center GeoPoint, radius string) *Shape {
+	return &Shape{Type: "circle", Coordinates: center.coordinates(), Radius: radius}
+}
+
+// NewGeometryCollectionShape creates a collection of shapes
+func NewGeometryCollectionShape(shapes ...*Shape) *Shape {
+	return &Shape{Type: "geometrycollection", Geometries: shapes}
+}
+
+// lineCoordinates returns the GeoJSON coordinates of a list of points
+func lineCoordinates(points []GeoPoint) [][]float64 {
+	coordinates := [][]float64{}
+	for _, point := range points {
+		coordinates = append(coordinates, point.coordinates())
+	}
+	return coordinates
+}
+
+// GeoDistanceClause a structure representing the 'geo_distance' query
+type GeoDistanceClause struct {
+	kv Dict
+}
+
+// GeoDistanceQuery creates a new 'geo_distance' query matching documents whose field is within the distance (e.g. 12km) of the given point
+func GeoDistanceQuery(field string, point GeoPoint, distance string) *GeoDistanceClause {
+	return &GeoDistanceClause{kv: Dict{field: point, Distance: distance}}
+}
+
+// Name returns the name of this query
+func (query *GeoDistanceClause) Name() string {
+	return GeoDistanceQueryName
+}
+
+// KV returns the body of this query as a dictionary
+func (query *GeoDistanceClause) KV() Dict {
+	return query.kv
+}
+
+// String returns a string representation of this query
+func (query *GeoDistanceClause) String() string {
+	return String(Dict{query.Name(): query.kv})
+}
+
+// DistanceType sets how distances are computed (i.e. arc or plane)
+func (query *GeoDistanceClause) DistanceType(distanceType string) *GeoDistanceClause {
+	query.kv[DistanceType] = distanceType
+	return query
+}
+
+// GeoBoundingBoxClause a structure representing the 'geo_bounding_box' query
+type GeoBoundingBoxClause struct {
+	field string
+	box   Dict
+	kv    Dict
+}
+
+// GeoBoundingBoxQuery creates a new 'geo_bounding_box' query matching documents whose field is within the given rectangle
+func GeoBoundingBoxQuery(field string, topLeft, bottomRight GeoPoint) *GeoBoundingBoxClause {
+	return &GeoBoundingBoxClause{
+		field: field,
+		box:   Dict{"top_left": topLeft, "bottom_right": bottomRight},
+		kv:    make(Dict),
+	}
+}
+
+// Name returns the name of this query
+func (query *GeoBoundingBoxClause) Name() string {
+	return GeoBoundingBox
+}
+
+// KV returns the body of this query as a dictionary
+func (query *GeoBoundingBoxClause) KV() Dict {
+	dict := Dict{query.field: query.box}
+	for k, v := range query.kv {
+		dict[k] = v
+	}
+	return dict
+}
+
+// String returns a string representation of this query
+func (query *GeoBoundingBoxClause) String() string {
+	return String(Dict{query.Name(): query.KV()})
+}
+
+// Type sets how the query is executed (i.e. memory or indexed)
+func (query *GeoBoundingBoxClause) Type(executionType string) *GeoBoundingBoxClause {
+	query.kv[TYPE] = executionType
+	return query
+}
+
+// GeoPolygonClause a structure representing the 'geo_polygon' query
+type GeoPolygonClause struct {
+	fieldQuery
+}
+
+// GeoPolygonQuery creates a new 'geo_polygon' query matching documents whose field is within the polygon defined by the given points
+func GeoPolygonQuery(field string, points ...GeoPoint) *GeoPolygonClause {
+	query := &GeoPolygonClause{newFieldQuery(GeoPolygon, field)}
+	query.params["points"] = points
+	return query
+}
+
+// GeoShapeClause a structure representing the 'geo_shape' query
+type GeoShapeClause struct {
+	fieldQuery
+}
+
+// GeoShapeQuery creates a new 'geo_shape' query matching documents whose shape field has the given relation with the given shape
+func GeoShapeQuery(field string, shape *Shape) *GeoShapeClause {
+	query := &GeoShapeClause{newFieldQuery(GeoShape, field)}
+	query.params["shape"] = shape
+	return query
+}
+
+// GeoIndexedShapeQuery creates a new 'geo_shape' query using a shape indexed in another document at the given path
+func GeoIndexedShapeQuery(field, index, id, path string) *GeoShapeClause {
+	query := &GeoShapeClause{newFieldQuery(GeoShape, field)}
+	query.params["indexed_shape"] = Dict{INDEX: index, "id": id, Path: path}
+	return query
+}
+
+// Relation sets the spatial relation between the shapes (i.e. intersects, disjoint, within, contains)
+func (query *GeoShapeClause) Relation(relation string) *GeoShapeClause {
+	query.params[Relation] = relation
+	return query
+}
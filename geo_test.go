@@ -0,0 +1,64 @@
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// test for geo points
+func TestGeoPoint(t *testing.T) {
+	actual := []string{
+		String(NewGeoPoint(40.715, -73.988)),
+		String(NewGeohashPoint("drm3btev3e86")),
+		String(NewGeoPoint(41.12, -71.34).AsWKT()),
+	}
+	expected := []string{
+		`{"lat":40.715,"lon":-73.988}`,
+		`"drm3btev3e86"`,
+		`"POINT (-71.34 41.12)"`,
+	}
+	equals(t, actual, expected)
+	// parse the different formats of geo points
+	input := []string{`{"lat":41.12,"lon":-71.34}`, `[-71.34,41.12]`, `"41.12,-71.34"`, `"POINT (-71.34 41.12)"`, `"drm3btev3e86"`}
+	points := []interface{}{NewGeoPoint(41.12, -71.34), NewGeoPoint(41.12, -71.34), NewGeoPoint(41.12, -71.34), NewGeoPoint(41.12, -71.34).AsWKT(), NewGeohashPoint("drm3btev3e86")}
+	var parsed []interface{}
+	for _, in := range input {
+		point := GeoPoint{}
+		if err := json.Unmarshal([]byte(in), &point); err != nil {
+			t.Error(err)
+		}
+		parsed = append(parsed, point)
+	}
+	equalsInterface(t, parsed, points)
+	// invalid geo points
+	for _, in := range []string{`"drm3btev3e8a"`, `"not a point"`, `""`, `"drm3btev3e86drm3"`} {
+		if err := json.Unmarshal([]byte(in), &GeoPoint{}); err == nil {
+			t.Error("Should not parse", in)
+		}
+	}
+}
+
+// test for geo queries and sort
+func TestGeoQueries(t *testing.T) {
+	actual := []string{
+		GeoDistanceQuery("location", NewGeoPoint(40.715, -73.988), "1km").DistanceType("plane").String(),
+		GeoBoundingBoxQuery("location", NewGeoPoint(40.8, -74.0), NewGeoPoint(40.7, -73.0)).Type("indexed").String(),
+		GeoPolygonQuery("location", NewGeoPoint(40, -70), NewGeoPoint(30, -80), NewGeoPoint(20, -90)).String(),
+		GeoShapeQuery("location", NewEnvelopeShape(NewGeoPoint(53, 13), NewGeoPoint(52, 14))).Relation("within").String(),
+		GeoShapeQuery("location", NewPolygonShape([]GeoPoint{NewGeoPoint(0, 100), NewGeoPoint(0, 101), NewGeoPoint(1, 101), NewGeoPoint(0, 100)})).String(),
+		GeoShapeQuery("location", NewCircleShape(NewGeoPoint(45, -45), "100m")).String(),
+		GeoIndexedShapeQuery("location", "shapes", "deu", "location").String(),
+		NewGeoDistanceSort("location", NewGeoPoint(40.715, -73.998)).Order(Asc).Unit("km").DistanceType("arc").String(),
+	}
+	expected := []string{
+		`{"geo_distance":{"distance":"1km","distance_type":"plane","location":{"lat":40.715,"lon":-73.988}}}`,
+		`{"geo_bounding_box":{"location":{"bottom_right":{"lat":40.7,"lon":-73},"top_left":{"lat":40.8,"lon":-74}},"type":"indexed"}}`,
+		`{"geo_polygon":{"location":{"points":[{"lat":40,"lon":-70},{"lat":30,"lon":-80},{"lat":20,"lon":-90}]}}}`,
+		`{"geo_shape":{"location":{"relation":"within","shape":{"type":"envelope","coordinates":[[13,53],[14,52]]}}}}`,
+		`{"geo_shape":{"location":{"shape":{"type":"polygon","coordinates":[[[100,0],[101,0],[101,1],[100,0]]]}}}}`,
+		`{"geo_shape":{"location":{"shape":{"type":"circle","coordinates":[-45,45],"radius":"100m"}}}}`,
+		`{"geo_shape":{"location":{"indexed_shape":{"id":"deu","index":"shapes","path":"location"}}}}`,
+		`{"_geo_distance":{"distance_type":"arc","location":{"lat":40.715,"lon":-73.998},"order":"asc","unit":"km"}}`,
+	}
+	equals(t, actual, expected)
+}
@@ -0,0 +1,192 @@
+package elastic
+
+import (
+	"encoding/json"
+)
+
+const (
+	// HIGHLIGHT constant name of the highlight parameter of a Search API query
+	HIGHLIGHT = "highlight"
+	// PreTags a highlight parameter defining the tags inserted before highlighted text (default <em>)
+	PreTags = "pre_tags"
+	// PostTags a highlight parameter defining the tags inserted after highlighted text (default </em>)
+	PostTags = "post_tags"
+	// FragmentSize a highlight parameter defining the size in characters of highlighted fragments
+	FragmentSize = "fragment_size"
+	// NumberOfFragments a highlight parameter defining the maximum number of fragments to return, 0 returns the whole field
+	NumberOfFragments = "number_of_fragments"
+	// HighlightQuery a highlight parameter defining a query used for highlighting instead of the search query
+	HighlightQuery = "highlight_query"
+	// RequireFieldMatch a highlight parameter, when set to false all fields are highlighted and not only the queried ones
+	RequireFieldMatch = "require_field_match"
+	// Unified the default highlighter type, it uses the Lucene Unified Highlighter
+	Unified = "unified"
+	// Plain the highlighter type using the standard Lucene highlighter
+	Plain = "plain"
+	// FastVector the highlighter type using the Lucene Fast Vector highlighter, it requires 'term_vector' set to with_positions_offsets in the mapping
+	FastVector = "fvh"
+)
+
+// Highlight a structure representing the highlighting of search results
+type Highlight struct {
+	kv     Dict
+	fields []*HighlightField
+}
+
+// HighlightField a structure representing the highlighting settings of a field
+type HighlightField struct {
+	name string
+	kv   Dict
+}
+
+// NewHighlight creates a new highlight definition
+func NewHighlight() *Highlight {
+	return &Highlight{kv: make(Dict)}
+}
+
+// NewHighlightField creates the highlight definition of the given field, it overrides the global settings
+func NewHighlightField(name string) *HighlightField {
+	return &HighlightField{name: name, kv: make(Dict)}
+}
+
+// Field adds a field to highlight with the global settings
+func (highlight *Highlight) Field(names ...string) *Highlight {
+	for _, name := range names {
+		highlight.fields = append(highlight.fields, NewHighlightField(name))
+	}
+	return highlight
+}
+
+// AddField adds a field to highlight with its own settings
+func (highlight *Highlight) AddField(fields ...*HighlightField) *Highlight {
+	highlight.fields = append(highlight.fields, fields...)
+	return highlight
+}
+
+// Tags sets the tags surrounding the highlighted text
+func (highlight *Highlight) Tags(pre, post []string) *Highlight {
+	setHighlightTags(highlight.kv, pre, post)
+	return highlight
+}
+
+// FragmentSize sets the size in characters of highlighted fragments
+func (highlight *Highlight) FragmentSize(size int) *Highlight {
+	highlight.kv[FragmentSize] = size
+	return highlight
+}
+
+// NumberOfFragments sets the maximum number of fragments to return
+func (highlight *Highlight) NumberOfFragments(number int) *Highlight {
+	highlight.kv[NumberOfFragments] = number
+	return highlight
+}
+
+// Type sets the highlighter type (i.e. unified, plain or fvh)
+func (highlight *Highlight) Type(highlighter string) *Highlight {
+	highlight.kv[TYPE] = highlighter
+	return highlight
+}
+
+// Query sets the query used for highlighting instead of the search query
+func (highlight *Highlight) Query(query Query) *Highlight {
+	highlight.kv[HighlightQuery] = clause{query: query, named: true}
+	return highlight
+}
+
+// RequireFieldMatch sets whether only the queried fields are highlighted
+func (highlight *Highlight) RequireFieldMatch(require bool) *Highlight {
+	highlight.kv[RequireFieldMatch] = require
+	return highlight
+}
+
+// Add adds a global highlight parameter
+func (highlight *Highlight) Add(name string, value interface{}) *Highlight {
+	highlight.kv[name] = value
+	return highlight
+}
+
+// Dict returns a dictionary representation of this highlight definition
+func (highlight *Highlight) Dict() Dict {
+	dict := make(Dict)
+	for k, v := range highlight.kv {
+		dict[k] = v
+	}
+	if len(highlight.fields) > 0 {
+		// fields are given as an array to keep their order
+		fields := []Dict{}
+		for _, field := range highlight.fields {
+			fields = append(fields, Dict{field.name: field.kv})
+		}
+		dict["fields"] = fields
+	}
+	return dict
+}
+
+// MarshalJSON renders this highlight definition, so that it can be kept as is in the body of a request
+func (highlight *Highlight) MarshalJSON() ([]byte, error) {
+	return json.Marshal(highlight.Dict())
+}
+
+// String returns a string representation of this highlight definition
+func (highlight *Highlight) String() string {
+	return String(highlight.Dict())
+}
+
+// Tags sets the tags surrounding the highlighted text of this field
+func (field *HighlightField) Tags(pre, post []string) *HighlightField {
+	setHighlightTags(field.kv, pre, post)
+	return field
+}
+
+// FragmentSize sets the size in characters of highlighted fragments of this field
+func (field *HighlightField) FragmentSize(size int) *HighlightField {
+	field.kv[FragmentSize] = size
+	return field
+}
+
+// NumberOfFragments sets the maximum number of fragments to return for this field
+func (field *HighlightField) NumberOfFragments(number int) *HighlightField {
+	field.kv[NumberOfFragments] = number
+	return field
+}
+
+// Type sets the highlighter type of this field (i.e. unified, plain or fvh)
+func (field *HighlightField) Type(highlighter string) *HighlightField {
+	field.kv[TYPE] = highlighter
+	return field
+}
+
+// Query sets the query used for highlighting this field
+func (field *HighlightField) Query(query Query) *HighlightField {
+	field.kv[HighlightQuery] = clause{query: query, named: true}
+	return field
+}
+
+// RequireFieldMatch sets whether this field is highlighted only when it's queried
+func (field *HighlightField) RequireFieldMatch(require bool) *HighlightField {
+	field.kv[RequireFieldMatch] = require
+	return field
+}
+
+// Add adds a highlight parameter to this field
+func (field *HighlightField) Add(name string, value interface{}) *HighlightField {
+	field.kv[name] = value
+	return field
+}
+
+// setHighlightTags sets the pre/post tags of highlight settings
+func setHighlightTags(kv Dict, pre, post []string) {
+	if len(pre) > 0 {
+		kv[PreTags] = pre
+	}
+	if len(post) > 0 {
+		kv[PostTags] = post
+	}
+}
+
+// Highlight sets the highlighting of the results of this search request, it's rendered with the request
+// so later changes to the highlight definition are kept
+func (search *Search) Highlight(highlight *Highlight) *Search {
+	search.query[HIGHLIGHT] = highlight
+	return search
+}
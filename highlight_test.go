@@ -0,0 +1,31 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for highlight definitions
+func TestHighlight(t *testing.T) {
+	actual := []string{
+		NewHighlight().Field("title", "body").String(),
+		NewHighlight().Tags([]string{"<b>"}, []string{"</b>"}).FragmentSize(150).NumberOfFragments(3).Type(Unified).AddField(NewHighlightField("body").Type(FastVector).NumberOfFragments(0)).String(),
+		NewHighlight().RequireFieldMatch(false).AddField(NewHighlightField("title").Query(NewMatch().Add("title", "brown fox"))).String(),
+		emptySearch().AddQuery(NewQuery("query").SetQuery(NewMatch().Add("title", "fox"))).Highlight(NewHighlight().Field("title")).String(),
+	}
+	expected := []string{
+		`{"fields":[{"title":{}},{"body":{}}]}`,
+		`{"fields":[{"body":{"number_of_fragments":0,"type":"fvh"}}],"fragment_size":150,"number_of_fragments":3,"post_tags":["\u003c/b\u003e"],"pre_tags":["\u003cb\u003e"],"type":"unified"}`,
+		`{"fields":[{"title":{"highlight_query":{"match":{"title":"brown fox"}}}}],"require_field_match":false}`,
+		`{"highlight":{"fields":[{"title":{}}]},"query":{"match":{"title":"fox"}}}`,
+	}
+	equals(t, actual, expected)
+	// fields and queries set after the highlight was attached are rendered
+	highlight := NewHighlight()
+	title := NewMatch().Add("title", "fox")
+	search := emptySearch().Highlight(highlight)
+	highlight.Field("title").Query(title)
+	title.Add("operator", "and")
+	equals(t, []string{search.String()}, []string{
+		`{"highlight":{"fields":[{"title":{}}],"highlight_query":{"match":{"operator":"and","title":"fox"}}}}`,
+	})
+}
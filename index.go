@@ -1,6 +1,7 @@
 package elastic
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -209,14 +210,28 @@ func (idx *Index) Pretty() *Index {
 // Put submits to elasticsearch the query to create an index
 // PUT /:index
 func (idx *Index) Put() {
+	if err := idx.put(context.Background()); err != nil {
+		log.Println(err)
+	}
+}
+
+// put submits the query to create an index, it returns an error if the index could not be created
+func (idx *Index) put(ctx context.Context) error {
 	url := idx.url
 	query := String(idx.dict)
 
-	idx.client.Execute("PUT", url, query, idx.parser)
+	return checkResult(idx.client.execute(ctx, "PUT", url, query, idx.parser))
 }
 
 // Delete submits to elasticsearch a query to delete an index
 // DELETE /:index
 func (idx *Index) Delete() {
-	idx.client.Execute("DELETE", idx.url, "", idx.parser)
+	if err := idx.delete(context.Background()); err != nil {
+		log.Println(err)
+	}
+}
+
+// delete submits a query to delete an index, it returns an error if the index could not be deleted
+func (idx *Index) delete(ctx context.Context) error {
+	return checkResult(idx.client.execute(ctx, "DELETE", idx.url, "", idx.parser))
 }
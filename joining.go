@@ -0,0 +1,235 @@
+package elastic
+
+import ()
+
+// Joining query names
+const (
+	// HasChild a query name. It matches parent documents whose child documents match a query.
+	HasChild = "has_child"
+	// HasParent a query name. It matches child documents whose parent document matches a query.
+	HasParent = "has_parent"
+	// ParentID a query name. It matches the child documents of a given parent document.
+	ParentID = "parent_id"
+	// INNERHITS constant name of the inner hits parameter of joining queries
+	INNERHITS = "inner_hits"
+	// IgnoreUnmapped a parameter of joining queries, when set to true indexes where the path or type is not mapped are ignored
+	IgnoreUnmapped = "ignore_unmapped"
+	// MinChildren a parameter of 'has_child' query defining the minimum number of matching children
+	MinChildren = "min_children"
+	// MaxChildren a parameter of 'has_child' query defining the maximum number of matching children
+	MaxChildren = "max_children"
+)
+
+// InnerHits a structure representing the inner hits of a joining query, i.e. the nested or child/parent documents that caused a hit
+type InnerHits struct {
+	kv Dict
+}
+
+// NewInnerHits creates a new inner hits definition
+func NewInnerHits() *InnerHits {
+	return &InnerHits{kv: make(Dict)}
+}
+
+// Name sets the name of the inner hits in the search result (the path or type by default)
+func (inner *InnerHits) Name(name string) *InnerHits {
+	inner.kv["name"] = name
+	return inner
+}
+
+// From sets the offset of the first inner hit returned
+func (inner *InnerHits) From(from int) *InnerHits {
+	inner.kv["from"] = from
+	return inner
+}
+
+// Size sets the maximum number of inner hits returned
+func (inner *InnerHits) Size(size int) *InnerHits {
+	inner.kv[Size] = size
+	return inner
+}
+
+// AddSort appends sort keys of the inner hits
+func (inner *InnerHits) AddSort(sorts ...*Sort) *InnerHits {
+	inner.kv[SORT] = appendSorts(inner.kv[SORT], sorts...)
+	return inner
+}
+
+// Source restricts the fields of the source of inner hits
+func (inner *InnerHits) Source(fields ...string) *InnerHits {
+	inner.kv[SOURCE] = fields
+	return inner
+}
+
+// DisableSource excludes the source of inner hits
+func (inner *InnerHits) DisableSource() *InnerHits {
+	inner.kv[SOURCE] = false
+	return inner
+}
+
+// Highlight sets the highlighting of inner hits
+func (inner *InnerHits) Highlight(highlight *Highlight) *InnerHits {
+	inner.kv[HIGHLIGHT] = highlight
+	return inner
+}
+
+// Add adds a parameter to the inner hits definition
+func (inner *InnerHits) Add(name string, value interface{}) *InnerHits {
+	inner.kv[name] = value
+	return inner
+}
+
+// String returns a string representation of this inner hits definition
+func (inner *InnerHits) String() string {
+	return String(inner.kv)
+}
+
+// joiningQuery a query that wraps another query, e.g. {"nested":{"path":"comments","query":{...}}}
+type joiningQuery struct {
+	name string
+	kv   Dict
+}
+
+// newJoiningQuery creates a new joining query wrapping the given query
+func newJoiningQuery(name string, query Query) joiningQuery {
+	kv := make(Dict)
+	if query != nil {
+		kv["query"] = Dict{query.Name(): query.KV()}
+	}
+	return joiningQuery{name: name, kv: kv}
+}
+
+// Name returns the name of this query
+func (query *joiningQuery) Name() string {
+	return query.name
+}
+
+// KV returns the body of this query as a dictionary
+func (query *joiningQuery) KV() Dict {
+	return query.kv
+}
+
+// String returns a string representation of this query
+func (query *joiningQuery) String() string {
+	return String(Dict{query.name: query.kv})
+}
+
+// NestedClause a structure representing the 'nested' query
+type NestedClause struct {
+	joiningQuery
+}
+
+// NestedQuery creates a new 'nested' query matching the nested objects at the given path
+func NestedQuery(path string, query Query) *NestedClause {
+	nested := &NestedClause{newJoiningQuery(Nested, query)}
+	nested.kv[Path] = path
+	return nested
+}
+
+// ScoreMode sets how the scores of the matching nested objects are combined (i.e. avg, max, min, none, sum)
+func (nested *NestedClause) ScoreMode(mode string) *NestedClause {
+	nested.kv[ScoreMode] = mode
+	return nested
+}
+
+// IgnoreUnmapped ignores the indexes where the path is not mapped instead of failing
+func (nested *NestedClause) IgnoreUnmapped(ignore bool) *NestedClause {
+	nested.kv[IgnoreUnmapped] = ignore
+	return nested
+}
+
+// InnerHits returns the matching nested objects with each hit
+func (nested *NestedClause) InnerHits(inner *InnerHits) *NestedClause {
+	nested.kv[INNERHITS] = inner.kv
+	return nested
+}
+
+// HasChildClause a structure representing the 'has_child' query
+type HasChildClause struct {
+	joiningQuery
+}
+
+// HasChildQuery creates a new 'has_child' query matching the parents of the child documents of the given type matching the given query
+func HasChildQuery(childType string, query Query) *HasChildClause {
+	child := &HasChildClause{newJoiningQuery(HasChild, query)}
+	child.kv[TYPE] = childType
+	return child
+}
+
+// ScoreMode sets how the scores of the matching children are combined (i.e. avg, max, min, none, sum)
+func (child *HasChildClause) ScoreMode(mode string) *HasChildClause {
+	child.kv[ScoreMode] = mode
+	return child
+}
+
+// MinChildren sets the minimum number of matching children
+func (child *HasChildClause) MinChildren(min int) *HasChildClause {
+	child.kv[MinChildren] = min
+	return child
+}
+
+// MaxChildren sets the maximum number of matching children
+func (child *HasChildClause) MaxChildren(max int) *HasChildClause {
+	child.kv[MaxChildren] = max
+	return child
+}
+
+// IgnoreUnmapped ignores the indexes where the type is not mapped instead of failing
+func (child *HasChildClause) IgnoreUnmapped(ignore bool) *HasChildClause {
+	child.kv[IgnoreUnmapped] = ignore
+	return child
+}
+
+// InnerHits returns the matching children with each hit
+func (child *HasChildClause) InnerHits(inner *InnerHits) *HasChildClause {
+	child.kv[INNERHITS] = inner.kv
+	return child
+}
+
+// HasParentClause a structure representing the 'has_parent' query
+type HasParentClause struct {
+	joiningQuery
+}
+
+// HasParentQuery creates a new 'has_parent' query matching the children of the parent documents of the given type matching the given query
+func HasParentQuery(parentType string, query Query) *HasParentClause {
+	parent := &HasParentClause{newJoiningQuery(HasParent, query)}
+	parent.kv["parent_type"] = parentType
+	return parent
+}
+
+// Score sets whether the score of the matching parent is used as the score of the children
+func (parent *HasParentClause) Score(score bool) *HasParentClause {
+	parent.kv["score"] = score
+	return parent
+}
+
+// IgnoreUnmapped ignores the indexes where the type is not mapped instead of failing
+func (parent *HasParentClause) IgnoreUnmapped(ignore bool) *HasParentClause {
+	parent.kv[IgnoreUnmapped] = ignore
+	return parent
+}
+
+// InnerHits returns the matching parent with each hit
+func (parent *HasParentClause) InnerHits(inner *InnerHits) *HasParentClause {
+	parent.kv[INNERHITS] = inner.kv
+	return parent
+}
+
+// ParentIDClause a structure representing the 'parent_id' query
+type ParentIDClause struct {
+	joiningQuery
+}
+
+// ParentIDQuery creates a new 'parent_id' query matching the child documents of the given type of the given parent
+func ParentIDQuery(childType, id string) *ParentIDClause {
+	parent := &ParentIDClause{newJoiningQuery(ParentID, nil)}
+	parent.kv[TYPE] = childType
+	parent.kv["id"] = id
+	return parent
+}
+
+// IgnoreUnmapped ignores the indexes where the type is not mapped instead of failing
+func (parent *ParentIDClause) IgnoreUnmapped(ignore bool) *ParentIDClause {
+	parent.kv[IgnoreUnmapped] = ignore
+	return parent
+}
@@ -0,0 +1,38 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for joining queries
+func TestJoiningQueries(t *testing.T) {
+	actual := []string{
+		NestedQuery("comments", MatchQuery("comments.name", "john")).ScoreMode("max").IgnoreUnmapped(true).String(),
+		NestedQuery("comments", MatchQuery("comments.name", "john")).InnerHits(NewInnerHits().Size(2).AddSort(NewSort("comments.date").Order(Desc)).Source("comments.name").Highlight(NewHighlight().Field("comments.name"))).String(),
+		HasChildQuery("employee", RangeQuery("dob").Gte("1980-01-01")).ScoreMode("sum").MinChildren(2).MaxChildren(10).String(),
+		HasParentQuery("branch", MatchQuery("country", "UK")).Score(true).InnerHits(NewInnerHits().Name("branches").DisableSource()).String(),
+		ParentIDQuery("employee", "london").String(),
+	}
+	expected := []string{
+		`{"nested":{"ignore_unmapped":true,"path":"comments","query":{"match":{"comments.name":{"query":"john"}}},"score_mode":"max"}}`,
+		`{"nested":{"inner_hits":{"_source":["comments.name"],"highlight":{"fields":[{"comments.name":{}}]},"size":2,"sort":[{"comments.date":{"order":"desc"}}]},"path":"comments","query":{"match":{"comments.name":{"query":"john"}}}}}`,
+		`{"has_child":{"max_children":10,"min_children":2,"query":{"range":{"dob":{"gte":"1980-01-01"}}},"score_mode":"sum","type":"employee"}}`,
+		`{"has_parent":{"inner_hits":{"_source":false,"name":"branches"},"parent_type":"branch","query":{"match":{"country":{"query":"UK"}}},"score":true}}`,
+		`{"parent_id":{"id":"london","type":"employee"}}`,
+	}
+	equals(t, actual, expected)
+}
+
+// test for parsing inner hits of a search result
+func TestInnerHitsResult(t *testing.T) {
+	parser := &SearchResultParser{}
+	input := []string{
+		`{"took":1,"timed_out":false,"_shards":{"total":1,"successful":1,"failed":0},"hits":{"total":1,"max_score":1.0,"hits":[{"_index":"blog","_type":"post","_id":"1","_score":1.0,"_source":{"title":"Nest eggs"},"inner_hits":{"comments":{"hits":{"total":1,"max_score":1.0,"hits":[{"_index":"blog","_type":"post","_id":"1","_nested":{"field":"comments","offset":1},"_score":1.0,"_source":{"name":"John"}}]}}}}]}}`,
+	}
+	expected := []interface{}{
+		SearchResult{Took: 1, Shards: Shard{Total: 1, Successful: 1}, Hits: Hits{Total: 1, MaxScore: 1.0, Hits: []SearchHits{SearchHits{Index: "blog", Type: "post", ID: "1", Score: 1, Source: Dict{"title": "Nest eggs"}, InnerHits: map[string]SearchResult{
+			"comments": SearchResult{Hits: Hits{Total: 1, MaxScore: 1.0, Hits: []SearchHits{SearchHits{Index: "blog", Type: "post", ID: "1", Score: 1, Source: Dict{"name": "John"}, Nested: &NestedIdentity{Field: "comments", Offset: 1}}}}},
+		}}}}},
+	}
+	checkParsingResult(t, input, parser, expected)
+}
@@ -0,0 +1,529 @@
+package elastic
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Severity the severity of a lint issue
+type Severity string
+
+const (
+	// SeverityError an issue that makes Elasticsearch reject the request
+	SeverityError Severity = "error"
+	// SeverityWarning an issue on a construct that is deprecated or removed in recent versions of Elasticsearch
+	SeverityWarning Severity = "warning"
+)
+
+// Issue a problem found by Lint in a search request
+type Issue struct {
+	// Path the location of the problem, e.g. query.bool.must[0].match
+	Path     string
+	Severity Severity
+	Message  string
+}
+
+// String returns a string representation of this issue
+func (issue Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s", issue.Severity, issue.Path, issue.Message)
+}
+
+// LintError the error returned when a search request enforcing lint has issues
+type LintError []Issue
+
+// Error returns the issues of this error
+func (issues LintError) Error() string {
+	messages := []string{}
+	for _, issue := range issues {
+		messages = append(messages, issue.String())
+	}
+	return strings.Join(messages, "; ")
+}
+
+// valueType the expected type of the value of a query parameter
+type valueType int
+
+const (
+	anyValue valueType = iota
+	stringValue
+	numberValue
+	boolValue
+	// textValue a string, a number or a boolean, e.g. the value of a term query
+	textValue
+	// stringOrNumberValue e.g. fuzziness (AUTO or 2) or minimum_should_match (75% or 2)
+	stringOrNumberValue
+	// stringsValue a string or an array of strings
+	stringsValue
+	objectValue
+	arrayValue
+	// queryValue a query, e.g. the 'query' of a nested query
+	queryValue
+	// queriesValue an array of queries, e.g. the 'queries' of a dis_max query
+	queriesValue
+	// clauseValue a query or an array of queries, e.g. the 'must' of a bool query
+	clauseValue
+	// functionsValue an array of scoring functions, i.e. the 'functions' of a function_score query
+	functionsValue
+	// factorValue the body of a 'field_value_factor' scoring function
+	factorValue
+)
+
+// fieldKind how a query refers to the field it applies to
+type fieldKind int
+
+const (
+	// noField the body of the query only has parameters, e.g. {"multi_match":{"query":"fox","fields":[...]}}
+	noField fieldKind = iota
+	// fieldParams the body of the query is the field and its parameters, e.g. {"match":{"title":{"query":"fox"}}}
+	fieldParams
+	// fieldValue the body of the query is the field value and the query parameters, e.g. {"terms":{"tag":["a","b"],"boost":2}}
+	fieldValue
+)
+
+// querySpec the specification of a query used to lint it
+type querySpec struct {
+	field fieldKind
+	// shorthand whether the field may be given a value instead of its parameters, e.g. {"term":{"tag":"a"}}
+	shorthand bool
+	// value the expected type of the field value for fieldValue queries
+	value valueType
+	// params the accepted parameters, nil if they are not checked
+	params map[string]valueType
+	// values the accepted values of some parameters
+	values map[string][]string
+	// deprecated a replacement for a deprecated query
+	deprecated string
+}
+
+// commonParams the parameters accepted by every query
+var commonParams = map[string]valueType{Boost: numberValue, "_name": stringValue}
+
+// textQueryParams the parameters of full text queries
+var textQueryParams = map[string]valueType{
+	"query": textValue, Operator: stringValue, Fuzziness: stringOrNumberValue, PrefixLength: numberValue,
+	MaxExpansions: numberValue, ANALYZER: stringValue, MinimumShouldMatch: stringOrNumberValue,
+	"zero_terms_query": stringValue, Lenient: boolValue, CutOffFrequency: numberValue,
+	"fuzzy_transpositions": boolValue, "fuzzy_rewrite": stringValue, "auto_generate_synonyms_phrase_query": boolValue,
+	SLOP: numberValue, TYPE: stringValue,
+}
+
+// querySpecs the specifications of the known queries
+var querySpecs = map[string]querySpec{
+	MATCH: {field: fieldParams, shorthand: true, params: textQueryParams},
+	MatchPhrase: {field: fieldParams, shorthand: true, params: map[string]valueType{
+		"query": textValue, ANALYZER: stringValue, SLOP: numberValue, "zero_terms_query": stringValue,
+	}},
+	MatchPhrasePrefix: {field: fieldParams, shorthand: true, params: map[string]valueType{
+		"query": textValue, ANALYZER: stringValue, SLOP: numberValue, MaxExpansions: numberValue,
+	}},
+	MultiMatch: {params: map[string]valueType{
+		"query": textValue, FIELDS: stringsValue, TYPE: stringValue, Operator: stringValue, ANALYZER: stringValue,
+		Fuzziness: stringOrNumberValue, PrefixLength: numberValue, MaxExpansions: numberValue,
+		MinimumShouldMatch: stringOrNumberValue, "tie_breaker": numberValue, SLOP: numberValue,
+		Lenient: boolValue, "zero_terms_query": stringValue, CutOffFrequency: numberValue,
+	}},
+	Common: {field: fieldParams, params: map[string]valueType{
+		"query": stringValue, CutOffFrequency: numberValue, "low_freq_operator": stringValue,
+		"high_freq_operator": stringValue, MinimumShouldMatch: anyValue, ANALYZER: stringValue,
+	}},
+	Term:  {field: fieldParams, shorthand: true, params: map[string]valueType{"value": textValue}},
+	Terms: {field: fieldValue, value: anyValue, params: map[string]valueType{}},
+	Range: {field: fieldParams, params: map[string]valueType{
+		Gt: anyValue, Gte: anyValue, Lt: anyValue, Lte: anyValue, Format: stringValue, TimeZone: stringValue, Relation: stringValue,
+	}},
+	Prefix: {field: fieldParams, shorthand: true, params: map[string]valueType{"value": stringValue, Rewrite: stringValue}},
+	Wildcard: {field: fieldParams, shorthand: true, params: map[string]valueType{
+		"value": stringValue, "wildcard": stringValue, Rewrite: stringValue,
+	}},
+	RegExp: {field: fieldParams, shorthand: true, params: map[string]valueType{
+		"value": stringValue, Flags: stringValue, MaxDeterminizedStates: numberValue, Rewrite: stringValue,
+	}},
+	Fuzzy: {field: fieldParams, shorthand: true, params: map[string]valueType{
+		"value": textValue, Fuzziness: stringOrNumberValue, PrefixLength: numberValue, MaxExpansions: numberValue,
+		"transpositions": boolValue, Rewrite: stringValue,
+	}},
+	Exists:       {params: map[string]valueType{Field: stringValue}},
+	IDs:          {params: map[string]valueType{Values: arrayValue, TYPE: stringsValue}},
+	MatchAll:     {params: map[string]valueType{}},
+	"match_none": {params: map[string]valueType{}},
+	"bool": {params: map[string]valueType{
+		"must": clauseValue, "must_not": clauseValue, "should": clauseValue, Filter: clauseValue,
+		MinimumShouldMatch: stringOrNumberValue, "disable_coord": boolValue, "adjust_pure_negative": boolValue,
+	}},
+	Boosting:      {params: map[string]valueType{"positive": queryValue, "negative": queryValue, NegativeBoost: numberValue}},
+	ConstantScore: {params: map[string]valueType{Filter: queryValue}},
+	DisMax:        {params: map[string]valueType{"queries": queriesValue, "tie_breaker": numberValue}},
+	FunctionScore: {
+		params: map[string]valueType{
+			"query": queryValue, Functions: functionsValue, ScoreMode: stringValue, BoostMode: stringValue,
+			MaxBoost: numberValue, MinScore: numberValue, Filter: queryValue, Weight: numberValue,
+			FieldValueFactor: factorValue, RandomScore: objectValue, ScriptScore: objectValue,
+			Gauss: objectValue, Exp: objectValue, Linear: objectValue,
+		},
+		values: map[string][]string{ScoreMode: scoreModes, BoostMode: boostModes},
+	},
+	Nested: {
+		params: map[string]valueType{
+			Path: stringValue, "query": queryValue, ScoreMode: stringValue, IgnoreUnmapped: boolValue, INNERHITS: objectValue,
+		},
+		values: map[string][]string{ScoreMode: {"avg", "max", "min", "none", "sum"}},
+	},
+	HasChild: {
+		params: map[string]valueType{
+			TYPE: stringValue, "query": queryValue, ScoreMode: stringValue, MinChildren: numberValue,
+			MaxChildren: numberValue, IgnoreUnmapped: boolValue, INNERHITS: objectValue,
+		},
+		values: map[string][]string{ScoreMode: {"avg", "max", "min", "none", "sum"}},
+	},
+	HasParent: {params: map[string]valueType{
+		"parent_type": stringValue, "query": queryValue, "score": boolValue, IgnoreUnmapped: boolValue, INNERHITS: objectValue,
+	}},
+	ParentID: {params: map[string]valueType{TYPE: stringValue, "id": textValue, IgnoreUnmapped: boolValue}},
+	GeoDistanceQueryName: {field: fieldValue, value: anyValue, params: map[string]valueType{
+		Distance: stringOrNumberValue, DistanceType: stringValue, "validation_method": stringValue,
+	}},
+	GeoBoundingBox: {field: fieldValue, value: objectValue, params: map[string]valueType{
+		TYPE: stringValue, "validation_method": stringValue,
+	}},
+	GeoPolygon: {field: fieldParams, params: map[string]valueType{"points": arrayValue}},
+	GeoShape: {field: fieldParams, params: map[string]valueType{
+		"shape": objectValue, "indexed_shape": objectValue, Relation: stringValue,
+	}},
+	QueryString: {},
+	Percolate: {params: map[string]valueType{
+		Field: stringValue, "document": objectValue, "documents": arrayValue, INDEX: stringValue, "id": textValue,
+		"routing": stringValue, "preference": stringValue, "version": numberValue, "name": stringValue,
+	}},
+	MoreLikeThis: {params: map[string]valueType{
+		FIELDS: stringsValue, Like: anyValue, Unlike: anyValue, MinTermFreq: numberValue, MaxQueryTerms: numberValue,
+		MinDocFreq: numberValue, MaxDocFreq: numberValue, MinimumShouldMatch: stringOrNumberValue, "include": boolValue,
+		"min_word_length": numberValue, "max_word_length": numberValue, "stop_words": arrayValue, ANALYZER: stringValue,
+		"boost_terms": numberValue,
+	}},
+	SimpleQueryString:    {},
+	"script":             {},
+	"type":               {},
+	"terms_set":          {},
+	"wrapper":            {},
+	"span_term":          {},
+	"span_multi":         {},
+	"span_first":         {},
+	"span_near":          {},
+	"span_or":            {},
+	"span_not":           {},
+	"span_containing":    {},
+	"span_within":        {},
+	"field_masking_span": {},
+	// deprecated queries, their nested queries are linted too
+	"filtered": {deprecated: "a 'bool' query with 'must' and 'filter' clauses", params: map[string]valueType{
+		"query": queryValue, Filter: queryValue, "strategy": stringValue,
+	}},
+	Missing: {deprecated: "a 'bool' query with a 'must_not' clause containing an 'exists' query", params: map[string]valueType{
+		Field: stringValue, "existence": boolValue, "null_value": boolValue,
+	}},
+	And:     {deprecated: "a 'bool' query with 'must' clauses", params: map[string]valueType{Filters: queriesValue}},
+	Or:      {deprecated: "a 'bool' query with 'should' clauses", params: map[string]valueType{Filters: queriesValue}},
+	"not":   {deprecated: "a 'bool' query with 'must_not' clauses", params: map[string]valueType{"query": queryValue, Filter: queryValue}},
+	"limit": {deprecated: "the 'terminate_after' parameter", params: map[string]valueType{"value": numberValue}},
+	"indices": {deprecated: "a query on the '_index' field", params: map[string]valueType{
+		"indices": stringsValue, "index": stringValue, "query": queryValue, "no_match_query": anyValue,
+	}},
+	"fuzzy_like_this":       {deprecated: "a 'match' query with fuzziness"},
+	"fuzzy_like_this_field": {deprecated: "a 'match' query with fuzziness"},
+}
+
+// functionSpec the specification of an entry of the 'functions' of a function_score query
+var functionSpec = querySpec{params: map[string]valueType{
+	Filter: queryValue, Weight: numberValue, FieldValueFactor: factorValue, RandomScore: objectValue, ScriptScore: objectValue,
+	Gauss: objectValue, Exp: objectValue, Linear: objectValue,
+}}
+
+// factorSpec the specification of the body of a 'field_value_factor' scoring function
+var factorSpec = querySpec{
+	params: map[string]valueType{Field: stringValue, Factor: numberValue, Modifer: stringValue, Missing: numberValue},
+	values: map[string][]string{Modifer: modifiers},
+}
+
+// Lint checks the queries of a search request (i.e. query, post_filter and filter aggregations) without sending it.
+// It reports unknown queries, unknown parameters, parameters with a wrong type and deprecated queries.
+func Lint(search *Search) []Issue {
+	linter := &linter{issues: []Issue{}}
+	// lint the rendered body, so that all builders are checked the same way
+	value, err := decode([]byte(String(search.query)))
+	if err != nil {
+		linter.add("", SeverityError, fmt.Sprintf("invalid body: %v", err))
+		return linter.issues
+	}
+	body, _ := value.(Dict)
+	for _, key := range sortedKeys(body) {
+		switch key {
+		case "query", "post_filter":
+			linter.query(key, body[key])
+		case Aggs, Aggregations:
+			linter.aggregations(key, body[key])
+		}
+	}
+	return linter.issues
+}
+
+// linter collects the issues found in a search request
+type linter struct {
+	issues []Issue
+}
+
+// add adds an issue
+func (l *linter) add(path string, severity Severity, message string) {
+	l.issues = append(l.issues, Issue{Path: path, Severity: severity, Message: message})
+}
+
+// query lints a query given as a dictionary with a single key, the query name
+func (l *linter) query(path string, value interface{}) {
+	dict, ok := value.(Dict)
+	if !ok || len(dict) != 1 {
+		l.add(path, SeverityError, fmt.Sprintf("expected a query with exactly one name, got %s", String(value)))
+		return
+	}
+	for name, body := range dict {
+		path := path + "." + name
+		spec, ok := querySpecs[name]
+		if !ok {
+			l.add(path, SeverityError, fmt.Sprintf("unknown query '%s'", name))
+			return
+		}
+		if spec.deprecated != "" {
+			l.add(path, SeverityWarning, fmt.Sprintf("query '%s' is deprecated, use %s instead", name, spec.deprecated))
+			if array, ok := body.([]interface{}); ok {
+				// e.g. {"and":[...]}
+				l.queries(path, queriesValue, array)
+				continue
+			}
+		}
+		params, ok := body.(Dict)
+		if !ok {
+			l.add(path, SeverityError, fmt.Sprintf("expected an object, got %s", String(body)))
+			return
+		}
+		l.body(path, name, spec, params)
+	}
+}
+
+// body lints the body of a query
+func (l *linter) body(path, name string, spec querySpec, body Dict) {
+	if spec.params == nil {
+		return
+	}
+	switch spec.field {
+	case fieldParams:
+		if len(body) != 1 {
+			l.add(path, SeverityError, fmt.Sprintf("query '%s' expects exactly one field, got %d", name, len(body)))
+			return
+		}
+		for field, value := range body {
+			params, ok := value.(Dict)
+			if !ok {
+				if !spec.shorthand || !isType(value, textValue) {
+					l.add(path+"."+field, SeverityError, fmt.Sprintf("expected the parameters of query '%s', got %s", name, String(value)))
+				}
+				return
+			}
+			l.params(path+"."+field, name, spec, params)
+		}
+	case fieldValue:
+		fields := 0
+		for _, key := range sortedKeys(body) {
+			if _, ok := spec.params[key]; ok {
+				continue
+			}
+			if _, ok := commonParams[key]; ok {
+				continue
+			}
+			fields++
+			if !isType(body[key], spec.value) {
+				l.add(path+"."+key, SeverityError, fmt.Sprintf("expected %s, got %s", typeName(spec.value), String(body[key])))
+			}
+		}
+		if fields != 1 {
+			l.add(path, SeverityError, fmt.Sprintf("query '%s' expects exactly one field, got %d", name, fields))
+		}
+		l.params(path, name, spec, body)
+	default:
+		l.params(path, name, spec, body)
+	}
+}
+
+// params lints the parameters of a query, unknown parameters are skipped for fieldValue queries as they are the field
+func (l *linter) params(path, name string, spec querySpec, params Dict) {
+	for _, key := range sortedKeys(params) {
+		value := params[key]
+		expected, ok := spec.params[key]
+		if !ok {
+			expected, ok = commonParams[key]
+		}
+		if !ok {
+			if spec.field != fieldValue {
+				l.add(path+"."+key, SeverityError, fmt.Sprintf("unknown parameter '%s' for query '%s'", key, name))
+			}
+			continue
+		}
+		switch expected {
+		case queryValue:
+			l.query(path+"."+key, value)
+		case queriesValue, clauseValue:
+			l.queries(path+"."+key, expected, value)
+		case functionsValue:
+			l.functions(path+"."+key, value)
+		case factorValue:
+			if factor, ok := value.(Dict); ok {
+				l.params(path+"."+key, FieldValueFactor, factorSpec, factor)
+			} else {
+				l.add(path+"."+key, SeverityError, fmt.Sprintf("expected %s, got %s", typeName(objectValue), String(value)))
+			}
+		default:
+			if !isType(value, expected) {
+				l.add(path+"."+key, SeverityError, fmt.Sprintf("expected %s, got %s", typeName(expected), String(value)))
+			} else if values, ok := spec.values[key]; ok && !containsString(values, fmt.Sprint(value)) {
+				l.add(path+"."+key, SeverityError, fmt.Sprintf("invalid value '%v', possible values: %v", value, values))
+			}
+		}
+	}
+}
+
+// queries lints an array of queries, or a single query if accepted
+func (l *linter) queries(path string, expected valueType, value interface{}) {
+	array, ok := value.([]interface{})
+	if !ok {
+		if expected == clauseValue {
+			l.query(path, value)
+		} else {
+			l.add(path, SeverityError, fmt.Sprintf("expected an array of queries, got %s", String(value)))
+		}
+		return
+	}
+	for i, item := range array {
+		l.query(fmt.Sprintf("%s[%d]", path, i), item)
+	}
+}
+
+// functions lints the scoring functions of a function_score query
+func (l *linter) functions(path string, value interface{}) {
+	array, ok := value.([]interface{})
+	if !ok {
+		l.add(path, SeverityError, fmt.Sprintf("expected %s, got %s", typeName(arrayValue), String(value)))
+		return
+	}
+	for i, item := range array {
+		function, ok := item.(Dict)
+		if !ok {
+			l.add(fmt.Sprintf("%s[%d]", path, i), SeverityError, fmt.Sprintf("expected %s, got %s", typeName(objectValue), String(item)))
+			continue
+		}
+		l.params(fmt.Sprintf("%s[%d]", path, i), "function", functionSpec, function)
+	}
+}
+
+// aggregations lints the queries of 'filter' and 'filters' aggregations and of their sub aggregations
+func (l *linter) aggregations(path string, value interface{}) {
+	aggs, ok := value.(Dict)
+	if !ok {
+		return
+	}
+	for _, name := range sortedKeys(aggs) {
+		agg, ok := aggs[name].(Dict)
+		if !ok {
+			continue
+		}
+		path := path + "." + name
+		for _, key := range sortedKeys(agg) {
+			switch key {
+			case Filter:
+				l.query(path+"."+key, agg[key])
+			case Filters:
+				filters, _ := agg[key].(Dict)
+				if named, ok := filters["filters"].(Dict); ok {
+					for _, filter := range sortedKeys(named) {
+						l.query(path+".filters.filters."+filter, named[filter])
+					}
+				} else if list, ok := filters["filters"].([]interface{}); ok {
+					l.queries(path+".filters.filters", queriesValue, list)
+				}
+			case Aggs, Aggregations:
+				l.aggregations(path+"."+key, agg[key])
+			}
+		}
+	}
+}
+
+// isType checks the type of a decoded JSON value
+func isType(value interface{}, expected valueType) bool {
+	switch expected {
+	case stringValue:
+		_, ok := value.(string)
+		return ok
+	case numberValue:
+		_, ok := value.(json.Number)
+		return ok
+	case boolValue:
+		_, ok := value.(bool)
+		return ok
+	case textValue:
+		return isType(value, stringValue) || isType(value, numberValue) || isType(value, boolValue)
+	case stringOrNumberValue:
+		return isType(value, stringValue) || isType(value, numberValue)
+	case stringsValue:
+		if isType(value, stringValue) {
+			return true
+		}
+		array, ok := value.([]interface{})
+		if !ok {
+			return false
+		}
+		for _, item := range array {
+			if !isType(item, stringValue) {
+				return false
+			}
+		}
+		return true
+	case objectValue:
+		_, ok := value.(Dict)
+		return ok
+	case arrayValue:
+		_, ok := value.([]interface{})
+		return ok
+	}
+	return true
+}
+
+// typeName returns a description of a value type
+func typeName(expected valueType) string {
+	switch expected {
+	case stringValue:
+		return "a string"
+	case numberValue:
+		return "a number"
+	case boolValue:
+		return "a boolean"
+	case textValue:
+		return "a string, a number or a boolean"
+	case stringOrNumberValue:
+		return "a string or a number"
+	case stringsValue:
+		return "a string or an array of strings"
+	case objectValue:
+		return "an object"
+	case arrayValue:
+		return "an array"
+	}
+	return "a value"
+}
+
+// sortedKeys returns the keys of a dictionary in order, so that issues are reported in a stable order
+func sortedKeys(dict Dict) []string {
+	keys := []string{}
+	for key := range dict {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
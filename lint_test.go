@@ -0,0 +1,105 @@
+package elastic
+
+import (
+	"strings"
+	"testing"
+)
+
+// test for linting search requests
+func TestLint(t *testing.T) {
+	// a valid search request
+	valid := newSearch(nil, "").AddQuery(NewQuery("query").SetQuery(
+		NewBool().
+			AddMust(MatchQuery("title", "fox").Operator(And).Fuzziness("AUTO")).
+			AddFilter(RangeQuery("price").Gte(10).Lt(20)).
+			AddShould(TermsQuery("tag", "a", "b").Boost(2)).
+			AddMustNot(NestedQuery("comments", TermQuery("comments.author", "bob")).ScoreMode("avg")),
+	))
+	if issues := Lint(valid); len(issues) != 0 {
+		t.Error("Should not have issues", issues)
+	}
+	// invalid search requests
+	actual := []string{}
+	for _, body := range []string{
+		`{"query":{"rage":{"price":{"gte":10}}}}`,
+		`{"query":{"bool":{"must":["fox"]}}}`,
+		`{"query":{"term":{"tag":{"value":"a","fuzziness":2}}}}`,
+		`{"query":{"match":{"title":{"query":"fox","operator":1}}}}`,
+		`{"query":{"filtered":{"query":{"match_all":{}}}}}`,
+		`{"post_filter":{"bool":{"must_not":{"missing":{"field":"tag"}}}}}`,
+		`{"query":{"function_score":{"score_mode":"total","query":{"match_all":{}}}}}`,
+		`{"query":{"dis_max":{"queries":{"match_all":{}}}}}`,
+		`{"aggs":{"red":{"filter":{"term":{"color":["red"]}}}}}`,
+		// the queries nested in deprecated queries are linted too
+		`{"query":{"filtered":{"query":{"rage":{}},"filter":{"term":{"tag":{"fuzziness":2}}}}}}`,
+		`{"query":{"or":{"filters":[{"rage":{}}]}}}`,
+	} {
+		search, err := ParseSearchBody([]byte(body))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, issue := range Lint(search) {
+			actual = append(actual, issue.String())
+		}
+	}
+	expected := []string{
+		"error: query.rage: unknown query 'rage'",
+		`error: query.bool.must[0]: expected a query with exactly one name, got "fox"`,
+		"error: query.term.tag.fuzziness: unknown parameter 'fuzziness' for query 'term'",
+		"error: query.match.title.operator: expected a string, got 1",
+		"warning: query.filtered: query 'filtered' is deprecated, use a 'bool' query with 'must' and 'filter' clauses instead",
+		"warning: post_filter.bool.must_not.missing: query 'missing' is deprecated, use a 'bool' query with a 'must_not' clause containing an 'exists' query instead",
+		"error: query.function_score.score_mode: invalid value 'total', possible values: [multiply sum avg first max min]",
+		`error: query.dis_max.queries: expected an array of queries, got {"match_all":{}}`,
+		`error: aggs.red.filter.term.color: expected the parameters of query 'term', got ["red"]`,
+		"warning: query.filtered: query 'filtered' is deprecated, use a 'bool' query with 'must' and 'filter' clauses instead",
+		"error: query.filtered.filter.term.tag.fuzziness: unknown parameter 'fuzziness' for query 'term'",
+		"error: query.filtered.query.rage: unknown query 'rage'",
+		"warning: query.or: query 'or' is deprecated, use a 'bool' query with 'should' clauses instead",
+		"error: query.or.filters[0].rage: unknown query 'rage'",
+	}
+	if len(actual) != len(expected) {
+		t.Error("Should find all the issues", actual)
+	}
+	equals(t, actual, expected)
+	and := emptySearch().Add("query", Dict{And: []Dict{{"rage": Dict{}}}})
+	if issues := Lint(and); len(issues) != 2 || issues[1].Path != "query.and[0].rage" {
+		t.Error("Should lint the queries of the array form of 'and'", issues)
+	}
+}
+
+// test for enforcing lint before sending a search request
+func TestEnforceLint(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(map[string]string{"GET /books/_search": `{"took":1,"hits":{"total":0,"hits":[]}}`}, &requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	search, _ := client.SearchBody("books", "", []byte(`{"query":{"rage":{"price":{"gte":10}}}}`))
+	if _, err := search.EnforceLint().Do(); err == nil {
+		t.Error("Should fail with lint issues")
+	} else if _, ok := err.(LintError); !ok {
+		t.Errorf("Should be a LintError %T", err)
+	}
+	if len(requests) != 0 {
+		t.Error("Should not send the request", requests)
+	}
+	search, _ = client.SearchBody("books", "", []byte(`{"query":{"range":{"price":{"gte":10}}}}`))
+	result, err := search.EnforceLint().Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Took != 1 || len(requests) != 1 {
+		t.Error("Should send the request", result, requests)
+	}
+	// Get does not send a request blocked by lint either
+	search, _ = client.SearchBody("books", "", []byte(`{"query":{"rage":{"price":{"gte":10}}}}`))
+	search.EnforceLint().Get()
+	if len(requests) != 1 {
+		t.Error("Should not send the request", requests)
+	}
+	// warnings do not block the request
+	search, _ = client.SearchBody("books", "", []byte(`{"query":{"missing":{"field":"price"}}}`))
+	if _, err := search.EnforceLint().Do(); err != nil || len(requests) != 2 {
+		t.Error("Should send the request with warnings", err, requests)
+	}
+}
@@ -0,0 +1,193 @@
+package elastic
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+)
+
+// MigrationStep the name of a step of an index migration
+type MigrationStep string
+
+// Steps of an index migration, in the order they are executed
+const (
+	// StepCreate creation of the new index with its settings and mappings
+	StepCreate MigrationStep = "create"
+	// StepReindex copy of the documents from the old index into the new one
+	StepReindex MigrationStep = "reindex"
+	// StepRefresh refresh of the new index so that copied documents are visible to search calls
+	StepRefresh MigrationStep = "refresh"
+	// StepAlias atomic move of the alias from the old index to the new one
+	StepAlias MigrationStep = "alias"
+	// StepDelete deletion of the old index
+	StepDelete MigrationStep = "delete"
+	// StepRollback deletion of the new index after a failed step
+	StepRollback MigrationStep = "rollback"
+)
+
+// Migration a structure representing a zero-downtime migration of the index behind an alias.
+// The new index is created, filled with the documents of the old one, refreshed, then the alias is atomically moved to it.
+type Migration struct {
+	client       *Elasticsearch
+	alias        string
+	from         string
+	to           string
+	index        *Index
+	reindex      *Reindex
+	pollInterval time.Duration
+	deleteOld    bool
+	dryRun       bool
+	rollback     bool
+	progress     func(step MigrationStep, description string)
+}
+
+// MigrateIndex creates a migration of the given alias from the index 'from' (e.g. name_v1) to the index 'to' (e.g. name_v2)
+func (client *Elasticsearch) MigrateIndex(alias, from, to string) *Migration {
+	return &Migration{
+		client:       client,
+		alias:        alias,
+		from:         from,
+		to:           to,
+		index:        client.Index(to),
+		reindex:      client.Reindex().Source(from).Dest(to),
+		pollInterval: time.Second,
+	}
+}
+
+// Settings sets the settings of the new index
+func (migration *Migration) Settings(settings Dict) *Migration {
+	migration.index.Settings(settings)
+	return migration
+}
+
+// Mappings sets the mapping of a document type of the new index
+func (migration *Migration) Mappings(doctype string, mapping *Mapping) *Migration {
+	migration.index.Mappings(doctype, mapping)
+	return migration
+}
+
+// AddAnalyzer adds an analyzer to the settings of the new index
+func (migration *Migration) AddAnalyzer(analyzer *Analyzer) *Migration {
+	migration.index.AddAnalyzer(analyzer)
+	return migration
+}
+
+// Reindex returns the Reindex request used to copy documents, it can be customized (e.g. with a query or a script)
+func (migration *Migration) Reindex() *Reindex {
+	return migration.reindex
+}
+
+// PollInterval sets how often the status of the reindex task is checked
+func (migration *Migration) PollInterval(interval time.Duration) *Migration {
+	migration.pollInterval = interval
+	return migration
+}
+
+// DeleteOld deletes the old index once the alias has been moved
+func (migration *Migration) DeleteOld() *Migration {
+	migration.deleteOld = true
+	return migration
+}
+
+// DryRun only reports the steps of the migration without submitting any request
+func (migration *Migration) DryRun() *Migration {
+	migration.dryRun = true
+	return migration
+}
+
+// RollbackOnFailure deletes the new index when a step fails before the alias has been moved
+func (migration *Migration) RollbackOnFailure() *Migration {
+	migration.rollback = true
+	return migration
+}
+
+// OnProgress sets a callback that is called before each step with a description of the request it submits
+func (migration *Migration) OnProgress(progress func(step MigrationStep, description string)) *Migration {
+	migration.progress = progress
+	return migration
+}
+
+// notify reports the given step to the progress callback
+func (migration *Migration) notify(step MigrationStep, description string) {
+	log.Println("migration", step, description)
+	if migration.progress != nil {
+		migration.progress(step, description)
+	}
+}
+
+// Run executes the migration steps in order, it stops at the first failing step.
+// The pending request is aborted when the context is done (e.g. cancelled while waiting for the reindex task).
+// If rollback is enabled, the new index is deleted when a step fails before the alias has been moved.
+func (migration *Migration) Run(ctx context.Context) (err error) {
+	created := false
+	defer func() {
+		if err != nil && created && migration.rollback {
+			migration.notify(StepRollback, "DELETE "+migration.index.url)
+			// the rollback is done even if the migration was cancelled
+			if rerr := migration.index.delete(context.Background()); rerr != nil {
+				err = fmt.Errorf("%v (rollback failed: %v)", err, rerr)
+			}
+		}
+	}()
+	// create the new index
+	if err = migration.step(ctx, StepCreate, "PUT "+migration.index.url+" "+migration.index.String(), migration.index.put); err != nil {
+		return err
+	}
+	created = !migration.dryRun
+	// copy the documents into the new index, and wait for the reindex task to complete
+	reindex := func(ctx context.Context) error {
+		id, err := migration.reindex.start(ctx)
+		if err != nil {
+			return err
+		}
+		result, err := migration.client.WaitForTask(ctx, id, migration.pollInterval)
+		if err != nil {
+			return err
+		}
+		// documents that were not copied would be lost once the alias is moved and the old index deleted
+		if result.Response != nil {
+			if err := result.Response.Err(); err != nil {
+				return fmt.Errorf("reindex task %s failed: %v", id, err)
+			}
+		}
+		return nil
+	}
+	if err = migration.step(ctx, StepReindex, "POST "+migration.reindex.urlString()+" "+migration.reindex.String(), reindex); err != nil {
+		return err
+	}
+	// make the copied documents visible
+	refresh := migration.client.Refresh(migration.to)
+	if err = migration.step(ctx, StepRefresh, "POST "+refresh.urlString(), refresh.post); err != nil {
+		return err
+	}
+	// atomically move the alias
+	alias := migration.client.Alias().AddAction("remove", migration.from, migration.alias).AddAction("add", migration.to, migration.alias)
+	if err = migration.step(ctx, StepAlias, "POST "+alias.url+" "+alias.String(), alias.post); err != nil {
+		return err
+	}
+	// the alias now points to the new index, it should be kept even if the old index cannot be deleted
+	created = false
+	if migration.deleteOld {
+		old := migration.client.Index(migration.from)
+		if err = migration.step(ctx, StepDelete, "DELETE "+old.url, old.delete); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// step reports and executes (unless in dry-run mode) a migration step
+func (migration *Migration) step(ctx context.Context, step MigrationStep, description string, do func(context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	migration.notify(step, description)
+	if migration.dryRun {
+		return nil
+	}
+	if err := do(ctx); err != nil {
+		return fmt.Errorf("migration step '%s' failed: %v", step, err)
+	}
+	return nil
+}
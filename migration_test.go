@@ -0,0 +1,84 @@
+package elastic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newTestServer creates a fake Elasticsearch server that records the submitted requests and responds with the given bodies (indexed by method and path)
+func newTestServer(responses map[string]string, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := r.Method + " " + r.URL.Path
+		*requests = append(*requests, request)
+		if body, ok := responses[request]; ok {
+			w.Write([]byte(body))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":{"type":"not_found","reason":"unexpected request"},"status":404}`))
+	}))
+}
+
+// test for the steps of an index migration
+func TestMigrateIndex(t *testing.T) {
+	responses := map[string]string{
+		"PUT /my_index_v2":           `{"acknowledged":true}`,
+		"POST /_reindex":             `{"task":"node1:42"}`,
+		"GET /_tasks/node1:42":       `{"completed":true,"task":{"node":"node1","id":42,"action":"indices:data/write/reindex","status":{"total":2,"created":2,"batches":1}},"response":{"took":10,"timed_out":false,"total":2,"created":2,"batches":1}}`,
+		"POST /my_index_v2/_refresh": `{"_shards":{"total":2,"successful":1,"failed":0}}`,
+		"POST /_aliases":             `{"acknowledged":true}`,
+		"DELETE /my_index_v1":        `{"acknowledged":true}`,
+	}
+	var requests []string
+	server := newTestServer(responses, &requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+
+	// dry run does not submit any request
+	var steps []string
+	err := client.MigrateIndex("my_index", "my_index_v1", "my_index_v2").DeleteOld().DryRun().OnProgress(func(step MigrationStep, description string) {
+		steps = append(steps, string(step))
+	}).Run(context.Background())
+	if err != nil || len(requests) != 0 {
+		t.Errorf("Dry run should not fail nor submit requests: %v %v", err, requests)
+	}
+	equals(t, steps, []string{"create", "reindex", "refresh", "alias", "delete"})
+
+	// successful migration
+	err = client.MigrateIndex("my_index", "my_index_v1", "my_index_v2").DeleteOld().Run(context.Background())
+	if err != nil {
+		t.Error("Migration should not fail", err)
+	}
+	expected := []string{"PUT /my_index_v2", "POST /_reindex", "GET /_tasks/node1:42", "POST /my_index_v2/_refresh", "POST /_aliases", "DELETE /my_index_v1"}
+	if len(requests) != len(expected) {
+		t.Errorf("Should be equal\n%v\n%v", requests, expected)
+	}
+	equals(t, requests, expected)
+
+	// failing alias step rolls back the new index
+	delete(responses, "POST /_aliases")
+	responses["DELETE /my_index_v2"] = `{"acknowledged":true}`
+	requests = nil
+	err = client.MigrateIndex("my_index", "my_index_v1", "my_index_v2").DeleteOld().RollbackOnFailure().Run(context.Background())
+	if err == nil {
+		t.Error("Migration should fail")
+	}
+	expected = []string{"PUT /my_index_v2", "POST /_reindex", "GET /_tasks/node1:42", "POST /my_index_v2/_refresh", "POST /_aliases", "DELETE /my_index_v2"}
+	if len(requests) != len(expected) {
+		t.Errorf("Should be equal\n%v\n%v", requests, expected)
+	}
+	equals(t, requests, expected)
+
+	// a reindex with failures stops the migration before the alias is moved, and the old index is kept
+	responses["GET /_tasks/node1:42"] = `{"completed":true,"task":{"node":"node1","id":42,"action":"indices:data/write/reindex"},"response":{"took":10,"timed_out":false,"total":2,"created":1,"batches":1,"failures":[{"index":"my_index_v2","id":"2","cause":{"type":"mapper_parsing_exception","reason":"failed to parse"},"status":400}]}}`
+	responses["POST /_aliases"] = `{"acknowledged":true}`
+	requests = nil
+	err = client.MigrateIndex("my_index", "my_index_v1", "my_index_v2").DeleteOld().Run(context.Background())
+	if err == nil {
+		t.Error("Migration should fail")
+	}
+	equals(t, requests, []string{"PUT /my_index_v2", "POST /_reindex", "GET /_tasks/node1:42"})
+}
@@ -0,0 +1,129 @@
+package elastic
+
+import ()
+
+const (
+	// MoreLikeThis a query name. It matches documents similar to given texts or documents.
+	MoreLikeThis = "more_like_this"
+	// Like a parameter of 'more_like_this' query listing the texts and documents to find similar documents to
+	Like = "like"
+	// Unlike a parameter of 'more_like_this' query listing the texts and documents whose terms are not selected
+	Unlike = "unlike"
+	// MinTermFreq a parameter of 'more_like_this' query, the minimum frequency of a term in the input to be selected (2 by default)
+	MinTermFreq = "min_term_freq"
+	// MaxQueryTerms a parameter of 'more_like_this' query, the maximum number of selected terms (25 by default)
+	MaxQueryTerms = "max_query_terms"
+	// MinDocFreq a parameter of 'more_like_this' query, the minimum number of documents containing a term for it to be selected (5 by default)
+	MinDocFreq = "min_doc_freq"
+	// MaxDocFreq a parameter of 'more_like_this' query, the maximum number of documents containing a term for it to be selected
+	MaxDocFreq = "max_doc_freq"
+)
+
+// MoreLikeThisClause a structure representing the 'more_like_this' query
+type MoreLikeThisClause struct {
+	kv Dict
+}
+
+// MoreLikeThisQuery creates a new 'more_like_this' query on the given fields (all the fields if none is given)
+func MoreLikeThisQuery(fields ...string) *MoreLikeThisClause {
+	query := &MoreLikeThisClause{kv: make(Dict)}
+	if len(fields) > 0 {
+		query.kv["fields"] = fields
+	}
+	return query
+}
+
+// Name returns the name of this query
+func (query *MoreLikeThisClause) Name() string {
+	return MoreLikeThis
+}
+
+// KV returns the body of this query as a dictionary
+func (query *MoreLikeThisClause) KV() Dict {
+	return query.kv
+}
+
+// String returns a string representation of this query
+func (query *MoreLikeThisClause) String() string {
+	return String(Dict{query.Name(): query.kv})
+}
+
+// add appends an item (a text or a document) to the 'like' or 'unlike' list
+func (query *MoreLikeThisClause) add(name string, item interface{}) *MoreLikeThisClause {
+	items, _ := query.kv[name].([]interface{})
+	query.kv[name] = append(items, item)
+	return query
+}
+
+// LikeText adds texts to find similar documents to
+func (query *MoreLikeThisClause) LikeText(texts ...string) *MoreLikeThisClause {
+	for _, text := range texts {
+		query.add(Like, text)
+	}
+	return query
+}
+
+// LikeDoc adds an indexed document to find similar documents to
+func (query *MoreLikeThisClause) LikeDoc(index, id string) *MoreLikeThisClause {
+	return query.add(Like, Dict{"_index": index, "_id": id})
+}
+
+// LikeArtificialDoc adds a document that is not indexed to find similar documents to, it's analyzed with the mapping of the index
+func (query *MoreLikeThisClause) LikeArtificialDoc(index string, doc Dict) *MoreLikeThisClause {
+	return query.add(Like, Dict{"_index": index, "doc": doc})
+}
+
+// UnlikeText adds texts whose terms are not selected
+func (query *MoreLikeThisClause) UnlikeText(texts ...string) *MoreLikeThisClause {
+	for _, text := range texts {
+		query.add(Unlike, text)
+	}
+	return query
+}
+
+// UnlikeDoc adds an indexed document whose terms are not selected
+func (query *MoreLikeThisClause) UnlikeDoc(index, id string) *MoreLikeThisClause {
+	return query.add(Unlike, Dict{"_index": index, "_id": id})
+}
+
+// MinTermFreq sets the minimum frequency of a term in the input for it to be selected
+func (query *MoreLikeThisClause) MinTermFreq(freq int) *MoreLikeThisClause {
+	query.kv[MinTermFreq] = freq
+	return query
+}
+
+// MaxQueryTerms sets the maximum number of selected terms
+func (query *MoreLikeThisClause) MaxQueryTerms(max int) *MoreLikeThisClause {
+	query.kv[MaxQueryTerms] = max
+	return query
+}
+
+// MinDocFreq sets the minimum number of documents containing a term for it to be selected
+func (query *MoreLikeThisClause) MinDocFreq(freq int) *MoreLikeThisClause {
+	query.kv[MinDocFreq] = freq
+	return query
+}
+
+// MaxDocFreq sets the maximum number of documents containing a term for it to be selected (e.g. to skip too common words)
+func (query *MoreLikeThisClause) MaxDocFreq(freq int) *MoreLikeThisClause {
+	query.kv[MaxDocFreq] = freq
+	return query
+}
+
+// MinimumShouldMatch sets the number (e.g. 2) or percentage (e.g. 30%) of selected terms that must match
+func (query *MoreLikeThisClause) MinimumShouldMatch(minimum interface{}) *MoreLikeThisClause {
+	query.kv[MinimumShouldMatch] = minimum
+	return query
+}
+
+// Include sets whether the input documents are returned in the results
+func (query *MoreLikeThisClause) Include(include bool) *MoreLikeThisClause {
+	query.kv["include"] = include
+	return query
+}
+
+// Boost sets the boost of this query
+func (query *MoreLikeThisClause) Boost(boost float32) *MoreLikeThisClause {
+	query.kv[Boost] = boost
+	return query
+}
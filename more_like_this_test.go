@@ -0,0 +1,30 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for more_like_this queries
+func TestMoreLikeThisQuery(t *testing.T) {
+	related := MoreLikeThisQuery("title", "body").
+		LikeDoc("articles", "1").
+		LikeText("elasticsearch relevance").
+		LikeArtificialDoc("articles", Dict{"title": "search engines"}).
+		UnlikeText("advertisement").
+		MinTermFreq(1).MaxQueryTerms(12).MinDocFreq(2).MinimumShouldMatch("30%")
+	actual := []string{
+		MoreLikeThisQuery().LikeText("fox").String(),
+		related.String(),
+		emptySearch().AddQuery(NewBool().AddMust(related).AddMustNot(IdsQuery("1"))).String(),
+	}
+	body := `{"fields":["title","body"],"like":[{"_id":"1","_index":"articles"},"elasticsearch relevance",{"_index":"articles","doc":{"title":"search engines"}}],"max_query_terms":12,"min_doc_freq":2,"min_term_freq":1,"minimum_should_match":"30%","unlike":["advertisement"]}`
+	expected := []string{
+		`{"more_like_this":{"like":["fox"]}}`,
+		`{"more_like_this":` + body + `}`,
+		`{"bool":{"must":[{"more_like_this":` + body + `}],"must_not":[{"ids":{"values":["1"]}}]}}`,
+	}
+	equals(t, actual, expected)
+	if issues := Lint(emptySearch().AddQuery(NewQuery("query").SetQuery(related))); len(issues) != 0 {
+		t.Error("Should not have issues", issues)
+	}
+}
@@ -0,0 +1,279 @@
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// mustacheNode a node of a parsed mustache template
+type mustacheNode struct {
+	// text the literal text of a text node
+	text string
+	// name the name of a variable or of a section
+	name string
+	// kind the kind of node: 0 text, '{' escaped variable, '&' raw variable, '#' section, '^' inverted section
+	kind byte
+	// children the content of a section
+	children []*mustacheNode
+	// source the unparsed content of a section, used by the toJson and join functions
+	source string
+}
+
+// RenderTemplate renders a search template locally, the way Elasticsearch does, so that templates can be tested offline.
+// It supports variables ({{name}}, {{a.b}}, {{list.0}}, {{.}}), raw variables ({{{name}}}, {{&name}}), sections ({{#name}}...{{/name}}),
+// inverted sections ({{^name}}...{{/name}}), comments ({{! ...}}) and the {{#toJson}}name{{/toJson}} and {{#join}}name{{/join}} functions.
+// Values of variables are escaped as in JSON strings.
+func RenderTemplate(source string, params Dict) (string, error) {
+	nodes, rest, err := parseMustache(source, "")
+	if err != nil {
+		return "", err
+	}
+	if rest != "" {
+		return "", fmt.Errorf("unexpected content %q", rest)
+	}
+	// normalize the parameters into decoded JSON values
+	var context interface{} = Dict{}
+	if params != nil {
+		if context, err = decode([]byte(String(params))); err != nil {
+			return "", err
+		}
+	}
+	buffer := &bytes.Buffer{}
+	if err := renderMustache(buffer, nodes, []interface{}{context}); err != nil {
+		return "", err
+	}
+	return buffer.String(), nil
+}
+
+// parseMustache parses a template until the closing tag of the given section, it returns the remaining source after the closing tag
+func parseMustache(source, section string) ([]*mustacheNode, string, error) {
+	nodes := []*mustacheNode{}
+	for {
+		start := strings.Index(source, "{{")
+		if start < 0 {
+			if section != "" {
+				return nil, "", fmt.Errorf("section '%s' is not closed", section)
+			}
+			if source != "" {
+				nodes = append(nodes, &mustacheNode{text: source})
+			}
+			return nodes, "", nil
+		}
+		if start > 0 {
+			nodes = append(nodes, &mustacheNode{text: source[:start]})
+		}
+		source = source[start:]
+		// find the end of the tag
+		closing := "}}"
+		if strings.HasPrefix(source, "{{{") {
+			closing = "}}}"
+		}
+		end := strings.Index(source, closing)
+		if end < 0 {
+			return nil, "", fmt.Errorf("tag is not closed: %s", source)
+		}
+		tag := source[2:end]
+		source = source[end+len(closing):]
+		if closing == "}}}" {
+			nodes = append(nodes, &mustacheNode{name: strings.TrimSpace(tag[1:]), kind: '&'})
+			continue
+		}
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			return nil, "", fmt.Errorf("empty tag")
+		}
+		switch tag[0] {
+		case '!':
+			// comment
+		case '&':
+			nodes = append(nodes, &mustacheNode{name: strings.TrimSpace(tag[1:]), kind: '&'})
+		case '#', '^':
+			name := strings.TrimSpace(tag[1:])
+			if name == "" {
+				return nil, "", fmt.Errorf("section without name")
+			}
+			children, rest, err := parseMustache(source, name)
+			if err != nil {
+				return nil, "", err
+			}
+			content := source[:len(source)-len(rest)]
+			content = content[:strings.LastIndex(content, "{{")]
+			nodes = append(nodes, &mustacheNode{name: name, kind: tag[0], children: children, source: content})
+			source = rest
+		case '/':
+			name := strings.TrimSpace(tag[1:])
+			if section == "" {
+				return nil, "", fmt.Errorf("unexpected closing tag '%s', no section is open", name)
+			}
+			if name != section {
+				return nil, "", fmt.Errorf("unexpected closing tag '%s', expected '%s'", name, section)
+			}
+			return nodes, source, nil
+		default:
+			nodes = append(nodes, &mustacheNode{name: tag, kind: '{'})
+		}
+	}
+}
+
+// renderMustache renders the parsed nodes with the given stack of contexts
+func renderMustache(buffer *bytes.Buffer, nodes []*mustacheNode, stack []interface{}) error {
+	for _, node := range nodes {
+		switch node.kind {
+		case 0:
+			buffer.WriteString(node.text)
+		case '{', '&':
+			value := lookupMustache(stack, node.name)
+			text := formatMustache(value)
+			if node.kind == '{' {
+				text = escapeJSONString(text)
+			}
+			buffer.WriteString(text)
+		case '^':
+			if !truthy(lookupMustache(stack, node.name)) {
+				if err := renderMustache(buffer, node.children, stack); err != nil {
+					return err
+				}
+			}
+		case '#':
+			if err := renderSection(buffer, node, stack); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// renderSection renders a section, or calls the toJson and join functions
+func renderSection(buffer *bytes.Buffer, node *mustacheNode, stack []interface{}) error {
+	if node.name == "toJson" {
+		buffer.WriteString(toJSON(lookupMustache(stack, strings.TrimSpace(node.source))))
+		return nil
+	}
+	if node.name == "join" || strings.HasPrefix(node.name, "join ") {
+		delimiter, err := joinDelimiter(node.name)
+		if err != nil {
+			return err
+		}
+		values, _ := lookupMustache(stack, strings.TrimSpace(node.source)).([]interface{})
+		items := []string{}
+		for _, value := range values {
+			items = append(items, escapeJSONString(formatMustache(value)))
+		}
+		buffer.WriteString(strings.Join(items, delimiter))
+		return nil
+	}
+	value := lookupMustache(stack, node.name)
+	if !truthy(value) {
+		return nil
+	}
+	if list, ok := value.([]interface{}); ok {
+		for _, item := range list {
+			if err := renderMustache(buffer, node.children, append(stack, item)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return renderMustache(buffer, node.children, append(stack, value))
+}
+
+// joinDelimiter returns the delimiter of a join function, e.g. {{#join delimiter='||'}}
+func joinDelimiter(name string) (string, error) {
+	option := strings.TrimSpace(strings.TrimPrefix(name, "join"))
+	if option == "" {
+		return ",", nil
+	}
+	if !strings.HasPrefix(option, "delimiter=") {
+		return "", fmt.Errorf("invalid join option '%s'", option)
+	}
+	delimiter := strings.TrimPrefix(option, "delimiter=")
+	if len(delimiter) < 2 || delimiter[0] != delimiter[len(delimiter)-1] || (delimiter[0] != '\'' && delimiter[0] != '"') {
+		return "", fmt.Errorf("invalid join delimiter %s", delimiter)
+	}
+	return delimiter[1 : len(delimiter)-1], nil
+}
+
+// lookupMustache finds the value of a (dotted) name in the stack of contexts, from the innermost one
+func lookupMustache(stack []interface{}, name string) interface{} {
+	if name == "." {
+		return stack[len(stack)-1]
+	}
+	parts := strings.Split(name, ".")
+	for i := len(stack) - 1; i >= 0; i-- {
+		value, ok := child(stack[i], parts[0])
+		if !ok {
+			continue
+		}
+		for _, part := range parts[1:] {
+			if value, ok = child(value, part); !ok {
+				return nil
+			}
+		}
+		return value
+	}
+	return nil
+}
+
+// child returns the value of a key of an object or of an index of an array
+func child(value interface{}, key string) (interface{}, bool) {
+	switch v := value.(type) {
+	case Dict:
+		item, ok := v[key]
+		return item, ok
+	case []interface{}:
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(v) {
+			return nil, false
+		}
+		return v[index], true
+	}
+	return nil, false
+}
+
+// truthy checks whether a section should be rendered for the given value
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != ""
+	case []interface{}:
+		return len(v) > 0
+	}
+	return true
+}
+
+// formatMustache formats the value of a variable
+func formatMustache(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case Dict, []interface{}:
+		return toJSON(v)
+	}
+	return fmt.Sprint(value)
+}
+
+// toJSON returns the JSON representation of a value without escaping HTML characters
+func toJSON(value interface{}) string {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return ""
+	}
+	return strings.TrimSuffix(buffer.String(), "\n")
+}
+
+// escapeJSONString escapes a text so that it can be placed inside a JSON string
+func escapeJSONString(text string) string {
+	quoted := toJSON(text)
+	return quoted[1 : len(quoted)-1]
+}
@@ -39,6 +39,23 @@ func (parser *FailureParser) Parse(data []byte) (interface{}, error) {
 	return nil, errors.New("Failed to parse response")
 }
 
+// ShardMgmtResultParser a parser for shard management result
+type ShardMgmtResultParser struct{}
+
+// Parse returns a shard management result structure from the given data
+func (parser *ShardMgmtResultParser) Parse(data []byte) (interface{}, error) {
+	result := ShardMgmtResult{}
+	if err := json.Unmarshal(data, &result); err == nil && result != *new(ShardMgmtResult) {
+		return result, nil
+	}
+	next := &FailureParser{}
+	if failure, err := next.Parse(data); err == nil && !deepEqual(failure, *new(Failure)) {
+		return failure, nil
+	}
+	log.Println("Failed to parse response", string(data))
+	return nil, errors.New("Failed to parse response")
+}
+
 // SearchResultParser a parser for search result
 type SearchResultParser struct{}
 
@@ -173,3 +190,104 @@ func (parser *AggregationResultParser) Parse(data []byte) (interface{}, error) {
 	log.Println(string(data))
 	return result, nil
 }
+
+// ReindexResultParser a parser for reindex result
+type ReindexResultParser struct{}
+
+// Parse returns a reindex result structure from the given data
+func (parser *ReindexResultParser) Parse(data []byte) (interface{}, error) {
+	reindex := ReindexResult{}
+	if err := json.Unmarshal(data, &reindex); err == nil && !deepEqual(reindex, *new(ReindexResult)) {
+		log.Println("reindex", reindex)
+		return reindex, nil
+	}
+	next := &FailureParser{}
+	if failure, err := next.Parse(data); err == nil && !deepEqual(failure, *new(Failure)) {
+		return failure, nil
+	}
+	log.Println("Failed to parse response", string(data))
+	return nil, errors.New("Failed to parse response")
+}
+
+// TasksResultParser a parser for tasks result
+type TasksResultParser struct{}
+
+// Parse returns a task (or task list) result structure from the given data
+func (parser *TasksResultParser) Parse(data []byte) (interface{}, error) {
+	task := TaskResult{}
+	if err := json.Unmarshal(data, &task); err == nil && !deepEqual(task.Task, *new(TaskInfo)) {
+		log.Println("task", task)
+		return task, nil
+	}
+	list := TaskListResult{}
+	if err := json.Unmarshal(data, &list); err == nil && !deepEqual(list, *new(TaskListResult)) {
+		log.Println("tasks", list)
+		return list, nil
+	}
+	next := &FailureParser{}
+	if failure, err := next.Parse(data); err == nil && !deepEqual(failure, *new(Failure)) {
+		return failure, nil
+	}
+	log.Println("Failed to parse response", string(data))
+	return nil, errors.New("Failed to parse response")
+}
+
+// RenderTemplateResultParser a parser for render template result
+type RenderTemplateResultParser struct{}
+
+// Parse returns a render template result structure from the given data
+func (parser *RenderTemplateResultParser) Parse(data []byte) (interface{}, error) {
+	render := RenderTemplateResult{}
+	if err := json.Unmarshal(data, &render); err == nil && !deepEqual(render, *new(RenderTemplateResult)) {
+		log.Println("render", string(render.TemplateOutput))
+		return render, nil
+	}
+	next := &FailureParser{}
+	if failure, err := next.Parse(data); err == nil && !deepEqual(failure, *new(Failure)) {
+		return failure, nil
+	}
+	log.Println("Failed to parse response", string(data))
+	return nil, errors.New("Failed to parse response")
+}
+
+// ExplainDocResultParser a parser for explain result
+type ExplainDocResultParser struct{}
+
+// Parse returns an explain result structure from the given data
+func (parser *ExplainDocResultParser) Parse(data []byte) (interface{}, error) {
+	explain := ExplainDocResult{}
+	if err := json.Unmarshal(data, &explain); err == nil && explain.ID != "" {
+		log.Println("explain", explain)
+		return explain, nil
+	}
+	next := &FailureParser{}
+	if failure, err := next.Parse(data); err == nil && !deepEqual(failure, *new(Failure)) {
+		return failure, nil
+	}
+	log.Println("Failed to parse response", string(data))
+	return nil, errors.New("Failed to parse response")
+}
+
+// ValidateResultParser a parser for validate query result
+type ValidateResultParser struct{}
+
+// Parse returns a validate query result structure from the given data
+func (parser *ValidateResultParser) Parse(data []byte) (interface{}, error) {
+	// a valid query or an invalid one are both results, the 'valid' field has to be present
+	probe := struct {
+		Valid *bool `json:"valid"`
+	}{}
+	validate := ExplainResult{}
+	if err := json.Unmarshal(data, &probe); err == nil && probe.Valid != nil {
+		if err := json.Unmarshal(data, &validate); err == nil {
+			log.Println("validate", validate)
+			return validate, nil
+		}
+	}
+	next := &FailureParser{}
+	if failure, err := next.Parse(data); err == nil && !deepEqual(failure, *new(Failure)) {
+		return failure, nil
+	}
+	log.Println("Failed to parse response", string(data))
+	return nil, errors.New("Failed to parse response")
+}
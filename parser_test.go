@@ -10,10 +10,12 @@ func TestSearchResultParser(t *testing.T) {
 	input := []string{
 		`{"took":3,"timed_out":false,"_shards":{"total":1,"successful":1,"failed":0},"hits":{"total":1,"max_score":0.50741017,"hits":[{"_index":"my_index","_type":"my_type","_id":"1","_score":0.50741017,"_source":{"name":"Brown foxes"}}]}}`,
 		`{"took":1,"timed_out":false,"_shards":{"total":5,"successful":5,"failed":0},"hits":{"total":0,"max_score":null,"hits":[]}}`,
+		`{"took":2,"timed_out":false,"_shards":{"total":1,"successful":1,"failed":0},"hits":{"total":1,"max_score":0.3,"hits":[{"_index":"my_index","_type":"my_type","_id":"1","_score":0.3,"_source":{"title":"Brown foxes"},"highlight":{"title":["Brown <em>foxes</em>"]}}]}}`,
 	}
 	expected := []interface{}{
 		SearchResult{Took: 3, TimedOut: false, Shards: Shard{Total: 1, Successful: 1, Failed: 0}, Hits: Hits{Total: 1, MaxScore: 0.50741017, Hits: []SearchHits{SearchHits{Index: "my_index", Type: "my_type", ID: "1", Score: 0.50741017, Source: Dict{"name": "Brown foxes"}}}}},
 		SearchResult{Took: 1, TimedOut: false, Shards: Shard{Total: 5, Successful: 5, Failed: 0}, Hits: Hits{Total: 0, MaxScore: nil, Hits: make([]SearchHits, 0)}},
+		SearchResult{Took: 2, TimedOut: false, Shards: Shard{Total: 1, Successful: 1, Failed: 0}, Hits: Hits{Total: 1, MaxScore: 0.3, Hits: []SearchHits{SearchHits{Index: "my_index", Type: "my_type", ID: "1", Score: 0.3, Source: Dict{"title": "Brown foxes"}, Highlight: map[string][]string{"title": []string{"Brown <em>foxes</em>"}}}}}},
 	}
 	checkParsingResult(t, input, parser, expected)
 }
@@ -0,0 +1,91 @@
+package elastic
+
+import ()
+
+const (
+	// Percolator the mapping type of a field storing queries, so that documents can be matched against them
+	Percolator = "percolator"
+	// Percolate a query name. It matches the stored queries that match the given documents.
+	Percolate = "percolate"
+	// PercolatorDocumentSlot the field of a percolate hit listing the positions of the given documents matched by the stored query
+	PercolatorDocumentSlot = "_percolator_document_slot"
+)
+
+// AddPercolatorField adds a field storing queries (i.e. with the 'percolator' type)
+func (mapping *Mapping) AddPercolatorField(name string) *Mapping {
+	return mapping.AddField(name, Dict{TYPE: Percolator})
+}
+
+// PercolatorDocument returns a document storing the given query in the given percolator field, along with other fields (e.g. the owner of the query).
+// It's indexed like any other document, e.g. client.Insert(index, doctype).Document(id, PercolatorDocument("query", query, nil)).Put()
+func PercolatorDocument(field string, query Query, fields Dict) Dict {
+	doc := Dict{field: Dict{query.Name(): query.KV()}}
+	for k, v := range fields {
+		doc[k] = v
+	}
+	return doc
+}
+
+// PercolateClause a structure representing the 'percolate' query
+type PercolateClause struct {
+	kv Dict
+}
+
+// PercolateQuery creates a new 'percolate' query matching the queries stored in the given field that match the given documents
+func PercolateQuery(field string, docs ...interface{}) *PercolateClause {
+	query := &PercolateClause{kv: Dict{Field: field}}
+	if len(docs) == 1 {
+		query.kv["document"] = docs[0]
+	} else {
+		query.kv["documents"] = docs
+	}
+	return query
+}
+
+// PercolateIndexedQuery creates a new 'percolate' query matching the queries stored in the given field that match an indexed document
+func PercolateIndexedQuery(field, index, id string) *PercolateClause {
+	return &PercolateClause{kv: Dict{Field: field, INDEX: index, "id": id}}
+}
+
+// Name returns the name of this query
+func (query *PercolateClause) Name() string {
+	return Percolate
+}
+
+// KV returns the body of this query as a dictionary
+func (query *PercolateClause) KV() Dict {
+	return query.kv
+}
+
+// String returns a string representation of this query
+func (query *PercolateClause) String() string {
+	return String(Dict{query.Name(): query.kv})
+}
+
+// QueryName sets the name of this query, it's needed to tell several percolate queries apart in the document slots of the hits
+func (query *PercolateClause) QueryName(name string) *PercolateClause {
+	query.kv["name"] = name
+	return query
+}
+
+// Routing sets the routing of the indexed document
+func (query *PercolateClause) Routing(routing string) *PercolateClause {
+	query.kv["routing"] = routing
+	return query
+}
+
+// PercolatorDocumentSlots returns the positions of the documents of a percolate query matched by the query of this hit.
+// The name of the percolate query is given when it's named, otherwise it's empty.
+func (hit SearchHits) PercolatorDocumentSlots(name string) []int {
+	field := PercolatorDocumentSlot
+	if name != "" {
+		field += "_" + name
+	}
+	slots := []int{}
+	for _, value := range hit.Fields[field] {
+		if slot, ok := value.(float64); ok {
+			slots = append(slots, int(slot))
+		}
+	}
+	return slots
+}
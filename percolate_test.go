@@ -0,0 +1,51 @@
+package elastic
+
+import (
+	"strings"
+	"testing"
+)
+
+// test for percolate queries
+func TestPercolateQuery(t *testing.T) {
+	saved := NewBool().AddMust(MatchQuery("title", "elasticsearch")).AddFilter(TermQuery("lang", "en"))
+	actual := []string{
+		NewMapping().AddPercolatorField("query").AddField("title", Dict{TYPE: "text"}).String(),
+		String(PercolatorDocument("query", saved, Dict{"user": "bob"})),
+		PercolateQuery("query", Dict{"title": "Elasticsearch rocks", "lang": "en"}).String(),
+		PercolateQuery("query", Dict{"title": "first"}, Dict{"title": "second"}).QueryName("news").String(),
+		PercolateIndexedQuery("query", "articles", "42").Routing("bob").String(),
+	}
+	expected := []string{
+		`{"properties":{"query":{"type":"percolator"},"title":{"type":"text"}}}`,
+		`{"query":{"bool":{"filter":[{"term":{"lang":{"value":"en"}}}],"must":[{"match":{"title":{"query":"elasticsearch"}}}]}},"user":"bob"}`,
+		`{"percolate":{"document":{"lang":"en","title":"Elasticsearch rocks"},"field":"query"}}`,
+		`{"percolate":{"documents":[{"title":"first"},{"title":"second"}],"field":"query","name":"news"}}`,
+		`{"percolate":{"field":"query","id":"42","index":"articles","routing":"bob"}}`,
+	}
+	equals(t, actual, expected)
+	if issues := Lint(emptySearch().AddQuery(NewQuery("query").SetQuery(PercolateQuery("query", Dict{"title": "x"})))); len(issues) != 0 {
+		t.Error("Should not have issues", issues)
+	}
+}
+
+// test for percolating documents against stored queries
+func TestPercolate(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(map[string]string{
+		"PUT /alerts/doc/1":   `{"_index":"alerts","_type":"doc","_id":"1","_version":1,"created":true}`,
+		"GET /alerts/_search": `{"took":1,"hits":{"total":1,"hits":[{"_index":"alerts","_type":"doc","_id":"1","_score":1.0,"_source":{"user":"bob"},"fields":{"_percolator_document_slot":[0,2],"_percolator_document_slot_news":[1]}}]}}`,
+	}, &requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	client.Insert("alerts", "doc").Document(1, PercolatorDocument("query", MatchQuery("title", "elasticsearch"), Dict{"user": "bob"})).Put()
+	docs := []interface{}{Dict{"title": "elasticsearch"}, Dict{"title": "lucene"}, Dict{"title": "elasticsearch rocks"}}
+	result, err := client.Search("alerts", "").AddQuery(NewQuery("query").SetQuery(PercolateQuery("query", docs...))).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	hit := result.Hits.Hits[0]
+	if len(hit.PercolatorDocumentSlots("")) != 2 || hit.PercolatorDocumentSlots("")[1] != 2 || hit.PercolatorDocumentSlots("news")[0] != 1 {
+		t.Error("Unexpected slots", hit.Fields)
+	}
+	equals(t, requests, []string{"PUT /alerts/doc/1", "GET /alerts/_search"})
+}
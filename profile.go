@@ -0,0 +1,96 @@
+package elastic
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// PROFILE constant name of the parameter enabling the profiling of a search request
+	PROFILE = "profile"
+)
+
+// Profile enables the profiling of this search request, the timing of each query component is returned in SearchResult.Profile
+func (search *Search) Profile(profile bool) *Search {
+	search.query[PROFILE] = profile
+	return search
+}
+
+// Tree returns the profile as an indented tree of the query components, aggregations and collectors of each shard.
+// At each level, only the 'limit' slowest children are kept (all of them if limit is 0) and they are sorted from the slowest.
+// e.g.
+//
+//	[node1][my_index][0]
+//	  query
+//	    BooleanQuery +title:fox #tag:a 1.2ms 100.0%
+//	      TermQuery title:fox 800µs 66.7%
+//	  rewrite 5µs
+//	  collector
+//	    SimpleTopScoreDocCollector (search_top_hits) 300µs
+func (profile *Profile) Tree(limit int) string {
+	buffer := &bytes.Buffer{}
+	for _, shard := range profile.Shards {
+		fmt.Fprintln(buffer, shard.ID)
+		for _, search := range shard.Searches {
+			fmt.Fprintln(buffer, "  query")
+			writeComponents(buffer, search.Query, limit, 2, totalTime(search.Query))
+			fmt.Fprintf(buffer, "  rewrite %s\n", time.Duration(search.RewriteTime))
+			fmt.Fprintln(buffer, "  collector")
+			writeCollectors(buffer, search.Collector, limit, 2)
+		}
+		if len(shard.Aggregations) > 0 {
+			fmt.Fprintln(buffer, "  aggregations")
+			writeComponents(buffer, shard.Aggregations, limit, 2, totalTime(shard.Aggregations))
+		}
+	}
+	return buffer.String()
+}
+
+// totalTime returns the time spent in the given components
+func totalTime(components []ProfileComponent) int64 {
+	var total int64
+	for _, component := range components {
+		total += component.TimeInNanos
+	}
+	return total
+}
+
+// slowest returns the 'limit' slowest components, sorted from the slowest
+func slowest(components []ProfileComponent, limit int) []ProfileComponent {
+	sorted := append([]ProfileComponent{}, components...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].TimeInNanos > sorted[j].TimeInNanos })
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	return sorted
+}
+
+// writeComponents writes the given components and their children, with their time and their share of the total time
+func writeComponents(buffer *bytes.Buffer, components []ProfileComponent, limit, depth int, total int64) {
+	indent := strings.Repeat("  ", depth)
+	for _, component := range slowest(components, limit) {
+		share := 0.0
+		if total > 0 {
+			share = 100 * float64(component.TimeInNanos) / float64(total)
+		}
+		fmt.Fprintf(buffer, "%s%s %s %s %.1f%%\n", indent, component.Type, component.Description, time.Duration(component.TimeInNanos), share)
+		writeComponents(buffer, component.Children, limit, depth+1, total)
+	}
+}
+
+// writeCollectors writes the given collectors and their children, sorted from the slowest
+func writeCollectors(buffer *bytes.Buffer, collectors []CollectorProfile, limit, depth int) {
+	sorted := append([]CollectorProfile{}, collectors...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].TimeInNanos > sorted[j].TimeInNanos })
+	if limit > 0 && len(sorted) > limit {
+		sorted = sorted[:limit]
+	}
+	indent := strings.Repeat("  ", depth)
+	for _, collector := range sorted {
+		fmt.Fprintf(buffer, "%s%s (%s) %s\n", indent, collector.Name, collector.Reason, time.Duration(collector.TimeInNanos))
+		writeCollectors(buffer, collector.Children, limit, depth+1)
+	}
+}
@@ -0,0 +1,37 @@
+package elastic
+
+import (
+	"strings"
+	"testing"
+)
+
+// test for profiling search requests
+func TestProfile(t *testing.T) {
+	search := newSearch(nil, "").AddQuery(NewQuery("query").SetQuery(MatchQuery("title", "fox"))).Profile(true)
+	equals(t, []string{search.String()}, []string{`{"profile":true,"query":{"match":{"title":{"query":"fox"}}}}`})
+	data := `{"took":3,"hits":{"total":1,"hits":[]},"profile":{"shards":[{"id":"[node1][books][0]","searches":[{"query":[{"type":"BooleanQuery","description":"title:fox title:dog","time_in_nanos":3000000,"breakdown":{"score":1000000,"create_weight":500000},"children":[{"type":"TermQuery","description":"title:fox","time_in_nanos":1000000},{"type":"TermQuery","description":"title:dog","time_in_nanos":2000000}]}],"rewrite_time":5000,"collector":[{"name":"SimpleTopScoreDocCollector","reason":"search_top_hits","time_in_nanos":300000}]}],"aggregations":[{"type":"GlobalOrdinalsStringTermsAggregator","description":"tags","time_in_nanos":1500000}]}]}}`
+	parser := &SearchResultParser{}
+	result, err := parser.Parse([]byte(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	profile := result.(SearchResult).Profile
+	if profile == nil || profile.Shards[0].Searches[0].Query[0].Breakdown["score"] != 1000000 {
+		t.Fatal("Unexpected profile", profile)
+	}
+	expected := []string{
+		"[node1][books][0]",
+		"  query",
+		"    BooleanQuery title:fox title:dog 3ms 100.0%",
+		"      TermQuery title:dog 2ms 66.7%",
+		"  rewrite 5µs",
+		"  collector",
+		"    SimpleTopScoreDocCollector (search_top_hits) 300µs",
+		"  aggregations",
+		"    GlobalOrdinalsStringTermsAggregator tags 1.5ms 100.0%",
+	}
+	equals(t, strings.Split(strings.TrimSpace(profile.Tree(1)), "\n"), expected)
+	if lines := strings.Split(strings.TrimSpace(profile.Tree(0)), "\n"); len(lines) != 10 || !strings.Contains(lines[4], "TermQuery title:fox 1ms 33.3%") {
+		t.Error("Should keep all the children", lines)
+	}
+}
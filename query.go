@@ -0,0 +1,394 @@
+package elastic
+
+import ()
+
+// Leaf query names
+const (
+	// Term a query name. It's a term-level query that matches documents containing the exact term.
+	Term = "term"
+	// IDs a query name. It matches documents by their identifiers.
+	IDs = "ids"
+	// Range a query name. It matches documents with a field value in the given range.
+	Range = "range"
+	// Exists a query name. It matches documents that have a value for the given field.
+	Exists = "exists"
+	// MatchAll a query name. It matches all documents.
+	MatchAll = "match_all"
+)
+
+// Leaf query parameters
+const (
+	// And a value of the 'operator' parameter, all terms must match
+	And = "and"
+	// Or a value of the 'operator' parameter, any term may match
+	Or = "or"
+	// Gt a range query parameter, greater than
+	Gt = "gt"
+	// Gte a range query parameter, greater than or equal
+	Gte = "gte"
+	// Lt a range query parameter, less than
+	Lt = "lt"
+	// Lte a range query parameter, less than or equal
+	Lte = "lte"
+	// TimeZone a range query parameter used to convert dates to UTC (e.g. +01:00)
+	TimeZone = "time_zone"
+	// Rewrite a multi-term query parameter defining how the query is rewritten (e.g. constant_score)
+	Rewrite = "rewrite"
+	// Flags a parameter of 'regexp' query enabling optional operators (e.g. ALL, INTERSECTION|COMPLEMENT)
+	Flags = "flags"
+	// MaxDeterminizedStates a parameter of 'regexp' query limiting the complexity of the regular expression
+	MaxDeterminizedStates = "max_determinized_states"
+)
+
+// fieldQuery a query on a single field, e.g. {"match":{"title":{"query":"fox"}}}
+type fieldQuery struct {
+	name   string
+	field  string
+	params Dict
+}
+
+// newFieldQuery creates a query with the given name on the given field
+func newFieldQuery(name, field string) fieldQuery {
+	return fieldQuery{name: name, field: field, params: make(Dict)}
+}
+
+// Name returns the name of this query
+func (query *fieldQuery) Name() string {
+	return query.name
+}
+
+// KV returns the body of this query as a dictionary
+func (query *fieldQuery) KV() Dict {
+	return Dict{query.field: query.params}
+}
+
+// String returns a string representation of this query
+func (query *fieldQuery) String() string {
+	return String(Dict{query.name: query.KV()})
+}
+
+// MatchClause a structure representing the 'match' full text query
+type MatchClause struct {
+	fieldQuery
+}
+
+// MatchQuery creates a new 'match' query of the given text on the given field
+func MatchQuery(field, text string) *MatchClause {
+	match := &MatchClause{newFieldQuery(MATCH, field)}
+	match.params["query"] = text
+	return match
+}
+
+// Operator sets the boolean operator used to combine the terms of the text (i.e. and, or)
+func (match *MatchClause) Operator(operator string) *MatchClause {
+	match.params[Operator] = operator
+	return match
+}
+
+// Fuzziness sets the maximum edit distance allowed for matching terms (e.g. AUTO, 1, 2)
+func (match *MatchClause) Fuzziness(fuzziness interface{}) *MatchClause {
+	match.params[Fuzziness] = fuzziness
+	return match
+}
+
+// PrefixLength sets the number of initial characters that are not fuzzified
+func (match *MatchClause) PrefixLength(length int) *MatchClause {
+	match.params[PrefixLength] = length
+	return match
+}
+
+// MaxExpansions sets the maximum number of terms a fuzzy term can expand to
+func (match *MatchClause) MaxExpansions(max int) *MatchClause {
+	match.params[MaxExpansions] = max
+	return match
+}
+
+// Analyzer sets the analyzer used on the text
+func (match *MatchClause) Analyzer(analyzer string) *MatchClause {
+	match.params[ANALYZER] = analyzer
+	return match
+}
+
+// MinimumShouldMatch sets the number (e.g. 2) or percentage (e.g. 75%) of terms that must match
+func (match *MatchClause) MinimumShouldMatch(minimum interface{}) *MatchClause {
+	match.params[MinimumShouldMatch] = minimum
+	return match
+}
+
+// Boost sets the boost of this query
+func (match *MatchClause) Boost(boost float32) *MatchClause {
+	match.params[Boost] = boost
+	return match
+}
+
+// RangeClause a structure representing the 'range' query
+type RangeClause struct {
+	fieldQuery
+}
+
+// RangeQuery creates a new 'range' query on the given field
+func RangeQuery(field string) *RangeClause {
+	return &RangeClause{newFieldQuery(Range, field)}
+}
+
+// Gt sets the lower bound (excluded) of this range
+func (r *RangeClause) Gt(value interface{}) *RangeClause {
+	r.params[Gt] = value
+	return r
+}
+
+// Gte sets the lower bound (included) of this range
+func (r *RangeClause) Gte(value interface{}) *RangeClause {
+	r.params[Gte] = value
+	return r
+}
+
+// Lt sets the upper bound (excluded) of this range
+func (r *RangeClause) Lt(value interface{}) *RangeClause {
+	r.params[Lt] = value
+	return r
+}
+
+// Lte sets the upper bound (included) of this range
+func (r *RangeClause) Lte(value interface{}) *RangeClause {
+	r.params[Lte] = value
+	return r
+}
+
+// Format sets the format of the dates of this range (e.g. dd/MM/yyyy)
+func (r *RangeClause) Format(format string) *RangeClause {
+	r.params[Format] = format
+	return r
+}
+
+// TimeZone sets the time zone of the dates of this range (e.g. +01:00)
+func (r *RangeClause) TimeZone(zone string) *RangeClause {
+	r.params[TimeZone] = zone
+	return r
+}
+
+// Boost sets the boost of this query
+func (r *RangeClause) Boost(boost float32) *RangeClause {
+	r.params[Boost] = boost
+	return r
+}
+
+// TermClause a structure representing the 'term' query
+type TermClause struct {
+	fieldQuery
+}
+
+// TermQuery creates a new 'term' query matching the exact value of the given field
+func TermQuery(field string, value interface{}) *TermClause {
+	term := &TermClause{newFieldQuery(Term, field)}
+	term.params["value"] = value
+	return term
+}
+
+// Boost sets the boost of this query
+func (term *TermClause) Boost(boost float32) *TermClause {
+	term.params[Boost] = boost
+	return term
+}
+
+// TermsClause a structure representing the 'terms' query
+type TermsClause struct {
+	field  string
+	values []interface{}
+	params Dict
+}
+
+// TermsQuery creates a new 'terms' query matching any of the exact values of the given field
+func TermsQuery(field string, values ...interface{}) *TermsClause {
+	return &TermsClause{field: field, values: values, params: make(Dict)}
+}
+
+// Name returns the name of this query
+func (terms *TermsClause) Name() string {
+	return Terms
+}
+
+// KV returns the body of this query as a dictionary
+func (terms *TermsClause) KV() Dict {
+	dict := Dict{terms.field: terms.values}
+	for k, v := range terms.params {
+		dict[k] = v
+	}
+	return dict
+}
+
+// String returns a string representation of this query
+func (terms *TermsClause) String() string {
+	return String(Dict{terms.Name(): terms.KV()})
+}
+
+// Boost sets the boost of this query
+func (terms *TermsClause) Boost(boost float32) *TermsClause {
+	terms.params[Boost] = boost
+	return terms
+}
+
+// IdsClause a structure representing the 'ids' query
+type IdsClause struct {
+	ids []string
+}
+
+// IdsQuery creates a new 'ids' query matching the documents with the given identifiers
+func IdsQuery(ids ...string) *IdsClause {
+	return &IdsClause{ids: ids}
+}
+
+// Name returns the name of this query
+func (ids *IdsClause) Name() string {
+	return IDs
+}
+
+// KV returns the body of this query as a dictionary
+func (ids *IdsClause) KV() Dict {
+	return Dict{Values: ids.ids}
+}
+
+// String returns a string representation of this query
+func (ids *IdsClause) String() string {
+	return String(Dict{ids.Name(): ids.KV()})
+}
+
+// PrefixClause a structure representing the 'prefix' query
+type PrefixClause struct {
+	fieldQuery
+}
+
+// PrefixQuery creates a new 'prefix' query matching the terms of the given field starting with the given prefix
+func PrefixQuery(field, prefix string) *PrefixClause {
+	query := &PrefixClause{newFieldQuery(Prefix, field)}
+	query.params["value"] = prefix
+	return query
+}
+
+// Rewrite sets how this query is rewritten (e.g. constant_score)
+func (query *PrefixClause) Rewrite(rewrite string) *PrefixClause {
+	query.params[Rewrite] = rewrite
+	return query
+}
+
+// Boost sets the boost of this query
+func (query *PrefixClause) Boost(boost float32) *PrefixClause {
+	query.params[Boost] = boost
+	return query
+}
+
+// WildcardClause a structure representing the 'wildcard' query
+type WildcardClause struct {
+	fieldQuery
+}
+
+// WildcardQuery creates a new 'wildcard' query matching the terms of the given field with the given pattern (i.e. ? and * wildcards)
+func WildcardQuery(field, pattern string) *WildcardClause {
+	query := &WildcardClause{newFieldQuery(Wildcard, field)}
+	query.params["value"] = pattern
+	return query
+}
+
+// Rewrite sets how this query is rewritten (e.g. constant_score)
+func (query *WildcardClause) Rewrite(rewrite string) *WildcardClause {
+	query.params[Rewrite] = rewrite
+	return query
+}
+
+// Boost sets the boost of this query
+func (query *WildcardClause) Boost(boost float32) *WildcardClause {
+	query.params[Boost] = boost
+	return query
+}
+
+// RegexpClause a structure representing the 'regexp' query
+type RegexpClause struct {
+	fieldQuery
+}
+
+// RegexpQuery creates a new 'regexp' query matching the terms of the given field with the given regular expression
+func RegexpQuery(field, regexp string) *RegexpClause {
+	query := &RegexpClause{newFieldQuery(RegExp, field)}
+	query.params["value"] = regexp
+	return query
+}
+
+// Flags sets the optional operators of the regular expression (e.g. ALL, INTERSECTION|COMPLEMENT)
+func (query *RegexpClause) Flags(flags string) *RegexpClause {
+	query.params[Flags] = flags
+	return query
+}
+
+// MaxDeterminizedStates sets the maximum complexity of the regular expression
+func (query *RegexpClause) MaxDeterminizedStates(max int) *RegexpClause {
+	query.params[MaxDeterminizedStates] = max
+	return query
+}
+
+// Rewrite sets how this query is rewritten (e.g. constant_score)
+func (query *RegexpClause) Rewrite(rewrite string) *RegexpClause {
+	query.params[Rewrite] = rewrite
+	return query
+}
+
+// Boost sets the boost of this query
+func (query *RegexpClause) Boost(boost float32) *RegexpClause {
+	query.params[Boost] = boost
+	return query
+}
+
+// ExistsClause a structure representing the 'exists' query
+type ExistsClause struct {
+	field string
+}
+
+// ExistsQuery creates a new 'exists' query matching the documents having a value for the given field
+func ExistsQuery(field string) *ExistsClause {
+	return &ExistsClause{field: field}
+}
+
+// Name returns the name of this query
+func (exists *ExistsClause) Name() string {
+	return Exists
+}
+
+// KV returns the body of this query as a dictionary
+func (exists *ExistsClause) KV() Dict {
+	return Dict{Field: exists.field}
+}
+
+// String returns a string representation of this query
+func (exists *ExistsClause) String() string {
+	return String(Dict{exists.Name(): exists.KV()})
+}
+
+// MatchAllClause a structure representing the 'match_all' query
+type MatchAllClause struct {
+	params Dict
+}
+
+// MatchAllQuery creates a new 'match_all' query matching all documents
+func MatchAllQuery() *MatchAllClause {
+	return &MatchAllClause{params: make(Dict)}
+}
+
+// Name returns the name of this query
+func (all *MatchAllClause) Name() string {
+	return MatchAll
+}
+
+// KV returns the body of this query as a dictionary
+func (all *MatchAllClause) KV() Dict {
+	return all.params
+}
+
+// String returns a string representation of this query
+func (all *MatchAllClause) String() string {
+	return String(Dict{all.Name(): all.KV()})
+}
+
+// Boost sets the boost of this query
+func (all *MatchAllClause) Boost(boost float32) *MatchAllClause {
+	all.params[Boost] = boost
+	return all
+}
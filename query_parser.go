@@ -0,0 +1,229 @@
+package elastic
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// boolClauses the clauses of a 'bool' query holding queries
+var boolClauses = []string{"must", "must_not", "should", Filter}
+
+// ParseQuery parses the JSON representation of a query (e.g. {"bool":{"must":[...]}}) into a query builder.
+// 'bool' and 'boosting' queries are parsed into Bool and BoostingQuery, other queries into Object.
+// Unknown clauses are kept verbatim so that rendering the parsed query gives back the same JSON.
+func ParseQuery(data []byte) (Query, error) {
+	value, err := decode(data)
+	if err != nil {
+		return nil, err
+	}
+	dict, ok := value.(Dict)
+	if !ok {
+		return nil, fmt.Errorf("a query should be an object: %s", string(data))
+	}
+	return parseQuery(dict)
+}
+
+// ParseSearchBody parses the JSON body of a Search API query (e.g. {"query":{...},"size":10}) into a Search request.
+// The 'query' is parsed with ParseQuery and can be retrieved with Search.Query, other parameters are kept verbatim.
+func ParseSearchBody(data []byte) (*Search, error) {
+	search := newSearch(nil, "")
+	if err := search.parseBody(data); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// SearchBody creates a Search request with the given JSON body (e.g. a saved search)
+func (client *Elasticsearch) SearchBody(index, class string, body []byte) (*Search, error) {
+	search := client.Search(index, class)
+	if err := search.parseBody(body); err != nil {
+		return nil, err
+	}
+	return search, nil
+}
+
+// parseBody parses the given JSON body into this search request
+func (search *Search) parseBody(data []byte) error {
+	value, err := decode(data)
+	if err != nil {
+		return err
+	}
+	body, ok := value.(Dict)
+	if !ok {
+		return fmt.Errorf("a search body should be an object: %s", string(data))
+	}
+	for key, value := range body {
+		if key == "query" {
+			dict, ok := value.(Dict)
+			if !ok {
+				return fmt.Errorf("invalid query: %s", String(value))
+			}
+			query, err := parseQuery(dict)
+			if err != nil {
+				return err
+			}
+			search.SetQuery(query)
+			continue
+		}
+		search.query[key] = value
+	}
+	return nil
+}
+
+// decode decodes JSON data, objects are decoded as Dict and numbers are kept verbatim
+func decode(data []byte) (interface{}, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return normalize(value), nil
+}
+
+// normalize converts decoded JSON objects into Dict
+func normalize(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		dict := make(Dict)
+		for key, item := range v {
+			dict[key] = normalize(item)
+		}
+		return dict
+	case []interface{}:
+		for i, item := range v {
+			v[i] = normalize(item)
+		}
+		return v
+	}
+	return value
+}
+
+// parseQuery parses a query given as a dictionary with a single key, the query name
+func parseQuery(dict Dict) (Query, error) {
+	if len(dict) != 1 {
+		return nil, fmt.Errorf("a query should have exactly one name: %s", String(dict))
+	}
+	for name, value := range dict {
+		body, ok := value.(Dict)
+		if !ok {
+			return nil, fmt.Errorf("invalid body of query '%s': %s", name, String(value))
+		}
+		switch name {
+		case "bool":
+			return parseBool(body), nil
+		case Boosting:
+			if boosting := parseBoosting(body); boosting != nil {
+				return boosting, nil
+			}
+		}
+		return parseObject(name, body), nil
+	}
+	return nil, errors.New("empty query")
+}
+
+// asQuery parses the given value if it's a query (i.e. a dictionary with a single key whose value is a dictionary)
+func asQuery(value interface{}) (Query, bool) {
+	dict, ok := value.(Dict)
+	if !ok || len(dict) != 1 {
+		return nil, false
+	}
+	for _, body := range dict {
+		if _, ok := body.(Dict); !ok {
+			return nil, false
+		}
+	}
+	query, err := parseQuery(dict)
+	return query, err == nil
+}
+
+// asQueries parses the given value if it's a non empty array of queries
+func asQueries(value interface{}) ([]Query, bool) {
+	array, ok := value.([]interface{})
+	if !ok || len(array) == 0 {
+		return nil, false
+	}
+	queries := []Query{}
+	for _, item := range array {
+		query, ok := asQuery(item)
+		if !ok {
+			return nil, false
+		}
+		queries = append(queries, query)
+	}
+	return queries, true
+}
+
+// parseBool parses the body of a 'bool' query
+func parseBool(body Dict) *Bool {
+	b := NewBool()
+	for key, value := range body {
+		if containsString(boolClauses, key) {
+			if query, ok := asQuery(value); ok {
+				// keep the object form of a single clause
+				b.kv[key] = clause{query: query, named: true}
+				continue
+			}
+			if queries, ok := asQueries(value); ok {
+				for _, query := range queries {
+					b.add(key, query)
+				}
+				continue
+			}
+		}
+		b.kv[key] = value
+	}
+	return b
+}
+
+// parseBoosting parses the body of a 'boosting' query, nil is returned if it has other clauses than the ones of BoostingQuery
+func parseBoosting(body Dict) *BoostingQuery {
+	positive, ok1 := asQuery(body["positive"])
+	negative, ok2 := asQuery(body["negative"])
+	negativeBoost, ok3 := body[NegativeBoost].(json.Number)
+	if !ok1 || !ok2 || !ok3 || len(body) != 3 {
+		return nil
+	}
+	value, err := negativeBoost.Float64()
+	if err != nil {
+		return nil
+	}
+	return NewBoosting().SetPositive(positive).SetNegative(negative).SetNegativeBoost(float32(value))
+}
+
+// parseObject parses the body of a generic query, the nested queries of compound queries (e.g. constant_score, dis_max) are parsed too
+func parseObject(name string, body Dict) *Object {
+	obj := NewQuery(name)
+	for key, value := range body {
+		switch key {
+		case "query", Filter, "positive", "negative":
+			if query, ok := asQuery(value); ok {
+				obj.kv[key] = clause{query: query, named: true}
+				continue
+			}
+		case "queries":
+			if queries, ok := asQueries(value); ok {
+				obj.AddQueries(key, queries...)
+				continue
+			}
+		}
+		obj.kv[key] = value
+	}
+	return obj
+}
+
+// SetQuery sets the main query of this search request, it's rendered with the request so later changes to the query are kept
+func (search *Search) SetQuery(query Query) *Search {
+	search.query["query"] = clause{query: query, named: true}
+	return search
+}
+
+// Query returns the main query of this search request, if it was set with SetQuery or parsed
+func (search *Search) Query() Query {
+	if c, ok := search.query["query"].(clause); ok {
+		return c.query
+	}
+	return nil
+}
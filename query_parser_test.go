@@ -0,0 +1,69 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for parsing queries into query builders
+func TestParseQuery(t *testing.T) {
+	input := []string{
+		`{"bool":{"filter":[{"term":{"status":"published"}}],"minimum_should_match":1,"must":{"match":{"title":"fox"}},"should":[{"match":{"body":"fox"}},{"range":{"date":{"gte":"now-1d"}}}]}}`,
+		`{"boosting":{"negative":{"match":{"text":"pie tart"}},"negative_boost":0.5,"positive":{"match":{"text":"apple"}}}}`,
+		`{"dis_max":{"queries":[{"match":{"title":"Brown fox"}},{"match":{"body":"Brown fox"}}],"tie_breaker":0.3}}`,
+		`{"constant_score":{"boost":1.2,"filter":{"bool":{"must_not":[{"exists":{"field":"deleted"}}]}}}}`,
+		`{"multi_match":{"fields":["title","body"],"query":"fox","type":"best_fields"}}`,
+		`{"unknown_query":{"big":12345678901234567890,"some":{"nested":[1,2.50,{"x":null}]}}}`,
+	}
+	for _, in := range input {
+		query, err := ParseQuery([]byte(in))
+		if err != nil {
+			t.Error(err)
+			continue
+		}
+		equals(t, []string{String(Dict{query.Name(): query.KV()})}, []string{in})
+	}
+	// check the types of the parsed queries
+	query, _ := ParseQuery([]byte(input[0]))
+	if _, ok := query.(*Bool); !ok {
+		t.Errorf("Should be a Bool %T", query)
+	}
+	query, _ = ParseQuery([]byte(input[1]))
+	if _, ok := query.(*BoostingQuery); !ok {
+		t.Errorf("Should be a BoostingQuery %T", query)
+	}
+	// parsed queries can be modified
+	query, _ = ParseQuery([]byte(`{"bool":{"must":{"match":{"title":"fox"}}}}`))
+	query.(*Bool).AddMust(TermQuery("lang", "en"))
+	equals(t, []string{String(Dict{query.Name(): query.KV()})}, []string{`{"bool":{"must":[{"match":{"title":"fox"}},{"term":{"lang":{"value":"en"}}}]}}`})
+	// invalid queries
+	for _, in := range []string{`[]`, `{"a":{},"b":{}}`, `{"match":"fox"}`, `{`} {
+		if _, err := ParseQuery([]byte(in)); err == nil {
+			t.Error("Should fail to parse", in)
+		}
+	}
+}
+
+// test for parsing search bodies
+func TestParseSearchBody(t *testing.T) {
+	in := `{"_source":["title"],"aggs":{"tags":{"terms":{"field":"tag"}}},"query":{"bool":{"must":[{"match":{"title":"fox"}}]}},"size":10,"sort":[{"date":{"order":"desc"}}]}`
+	search, err := ParseSearchBody([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, []string{search.String()}, []string{in})
+	// add a filter and change the size
+	search.Query().(*Bool).AddFilter(TermQuery("status", "published"))
+	search.Add(Size, 20)
+	expected := `{"_source":["title"],"aggs":{"tags":{"terms":{"field":"tag"}}},"query":{"bool":{"filter":[{"term":{"status":{"value":"published"}}}],"must":[{"match":{"title":"fox"}}]}},"size":20,"sort":[{"date":{"order":"desc"}}]}`
+	equals(t, []string{search.String()}, []string{expected})
+	// the body is rendered from the parsed queries, the nested queries can be navigated and changed
+	search, _ = ParseSearchBody([]byte(`{"query":{"boosting":{"negative":{"match":{"text":"pie"}},"negative_boost":0.5,"positive":{"constant_score":{"filter":{"bool":{"must":{"term":{"lang":"en"}}}}}}}}}`))
+	boosting := search.Query().(*BoostingQuery)
+	boosting.SetNegativeBoost(0.2)
+	filter := boosting.Positive().(*Object).Clauses(Filter)[0].(*Bool)
+	filter.Clauses("must")[0].(*Object).Add("lang", "fr")
+	filter.AddMustNot(TermQuery("deleted", true))
+	boosting.Negative().(*Object).Add("text", "tart")
+	expected = `{"query":{"boosting":{"negative":{"match":{"text":"tart"}},"negative_boost":0.2,"positive":{"constant_score":{"filter":{"bool":{"must":{"term":{"lang":"fr"}},"must_not":[{"term":{"deleted":{"value":true}}}]}}}}}}}`
+	equals(t, []string{search.String()}, []string{expected})
+}
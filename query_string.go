@@ -0,0 +1,302 @@
+package elastic
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// QueryString a query name. It parses a text with the Lucene query syntax (e.g. title:(quick OR brown) AND fox).
+	QueryString = "query_string"
+	// SimpleQueryString a query name. It parses a text with a simpler syntax that never fails, invalid parts are ignored.
+	SimpleQueryString = "simple_query_string"
+	// DefaultOperator a parameter of query_string queries, the operator used between terms without an explicit operator (i.e. OR, AND)
+	DefaultOperator = "default_operator"
+	// DefaultField a parameter of 'query_string' query, the field queried when no field is given in the text
+	DefaultField = "default_field"
+	// Lenient a parameter of full text queries, when set to true format based errors (e.g. a text on a numeric field) are ignored
+	Lenient = "lenient"
+)
+
+// simpleQueryStringParams the parameters of 'query_string' query kept when falling back to 'simple_query_string' query
+var simpleQueryStringParams = []string{
+	"query", "fields", DefaultOperator, ANALYZER, Flags, Lenient, MinimumShouldMatch, "analyze_wildcard",
+	"quote_field_suffix", "auto_generate_synonyms_phrase_query", "fuzzy_max_expansions", "fuzzy_prefix_length",
+	"fuzzy_transpositions", Boost, "_name",
+}
+
+// queryStringReserved the characters with a special meaning in the query string syntax, they are escaped with a backslash
+const queryStringReserved = `+-=&|!(){}[]^"~*?:\/`
+
+// queryStringOperators the operator words of the query string syntax
+var queryStringOperators = regexp.MustCompile(`\b(AND|OR|NOT)\b`)
+
+// EscapeQueryString escapes the reserved characters of a user input so that it's searched as is by a 'query_string' query.
+// The characters < and > are removed as they cannot be escaped, and the operator words (AND, OR, NOT) are lowercased.
+func EscapeQueryString(text string) string {
+	text = queryStringOperators.ReplaceAllStringFunc(text, strings.ToLower)
+	escaped := &strings.Builder{}
+	for _, c := range text {
+		if c == '<' || c == '>' {
+			continue
+		}
+		if strings.ContainsRune(queryStringReserved, c) {
+			escaped.WriteRune('\\')
+		}
+		escaped.WriteRune(c)
+	}
+	return escaped.String()
+}
+
+// CheckQueryString checks the syntax of a 'query_string' text, it detects the common errors rejected by Elasticsearch:
+// unbalanced parentheses, brackets and quotes, a trailing backslash and operators without operands
+func CheckQueryString(text string) error {
+	stack := []rune{}
+	quoted := false
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		if c == '\\' {
+			if i == len(runes)-1 {
+				return errors.New("the text ends with an escape character")
+			}
+			i++
+			continue
+		}
+		if c == '"' {
+			quoted = !quoted
+			continue
+		}
+		if quoted {
+			continue
+		}
+		switch c {
+		case '(', '[', '{':
+			stack = append(stack, c)
+		case ')', ']', '}':
+			if len(stack) == 0 || (c == ')' && stack[len(stack)-1] != '(') || (c != ')' && stack[len(stack)-1] == '(') {
+				return fmt.Errorf("unexpected '%c' at %d", c, i)
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if quoted {
+		return errors.New("a quote is not closed")
+	}
+	if len(stack) > 0 {
+		return fmt.Errorf("'%c' is not closed", stack[len(stack)-1])
+	}
+	// operators need operands on both sides
+	words := strings.Fields(text)
+	for i, word := range words {
+		switch word {
+		case "AND", "OR", "&&", "||":
+			if i == 0 || i == len(words)-1 {
+				return fmt.Errorf("operator '%s' without operand", word)
+			}
+		case "NOT", "!", "+", "-":
+			if i == len(words)-1 {
+				return fmt.Errorf("operator '%s' without operand", word)
+			}
+		}
+		if strings.HasSuffix(word, ":") && !strings.HasSuffix(word, `\:`) && i == len(words)-1 {
+			return fmt.Errorf("field '%s' without value", strings.TrimSuffix(word, ":"))
+		}
+	}
+	return nil
+}
+
+// QueryStringClause a structure representing the 'query_string' query
+type QueryStringClause struct {
+	kv       Dict
+	fallback bool
+	// rejected whether Elasticsearch failed to parse the text of this query
+	rejected bool
+}
+
+// QueryStringQuery creates a new 'query_string' query of the given text in the Lucene query syntax
+func QueryStringQuery(text string) *QueryStringClause {
+	return &QueryStringClause{kv: Dict{"query": text}}
+}
+
+// Name returns the name of this query, 'simple_query_string' when it falls back to it
+func (query *QueryStringClause) Name() string {
+	if query.fallsBack() {
+		return SimpleQueryString
+	}
+	return QueryString
+}
+
+// KV returns the body of this query as a dictionary
+func (query *QueryStringClause) KV() Dict {
+	if !query.fallsBack() {
+		return query.kv
+	}
+	dict := make(Dict)
+	for k, v := range query.kv {
+		if containsString(simpleQueryStringParams, k) {
+			dict[k] = v
+		}
+	}
+	if field, ok := query.kv[DefaultField]; ok && query.kv["fields"] == nil {
+		dict["fields"] = []interface{}{field}
+	}
+	return dict
+}
+
+// String returns a string representation of this query
+func (query *QueryStringClause) String() string {
+	return String(Dict{query.Name(): query.KV()})
+}
+
+// fallsBack whether this query is rendered as a 'simple_query_string' query
+func (query *QueryStringClause) fallsBack() bool {
+	if !query.fallback {
+		return false
+	}
+	if query.rejected {
+		return true
+	}
+	text, _ := query.kv["query"].(string)
+	return CheckQueryString(text) != nil
+}
+
+// FallbackToSimple renders this query as a 'simple_query_string' query when Elasticsearch fails to parse its text,
+// so that a user input with a broken syntax still returns results instead of failing the search.
+// The texts with common errors (see CheckQueryString) are rendered as 'simple_query_string' without a round trip,
+// the other ones are sent as 'query_string' and Search.Do sends the request again if Elasticsearch rejects them.
+func (query *QueryStringClause) FallbackToSimple() *QueryStringClause {
+	query.fallback = true
+	return query
+}
+
+// fallBackToSimple renders as 'simple_query_string' the queries of this search request that fall back to it
+// but were sent as 'query_string', it returns whether there are such queries
+func (search *Search) fallBackToSimple() bool {
+	fallback := false
+	visitQueries(search.query, func(query Query) {
+		if q, ok := query.(*QueryStringClause); ok && q.fallback && !q.fallsBack() {
+			q.rejected = true
+			fallback = true
+		}
+	})
+	return fallback
+}
+
+// isQueryParseFailure checks if Elasticsearch rejected a request because it failed to parse the text of a query
+func isQueryParseFailure(failure Failure) bool {
+	causes := append([]Dict{{"type": failure.Err.Type, "reason": failure.Err.Reason}}, failure.Err.RootCause...)
+	for _, cause := range causes {
+		kind, _ := cause["type"].(string)
+		reason, _ := cause["reason"].(string)
+		if kind == "query_parsing_exception" || kind == "parse_exception" || strings.HasPrefix(reason, "Failed to parse query") {
+			return true
+		}
+	}
+	return false
+}
+
+// Fields sets the fields to query, with optional boosts (e.g. title^3)
+func (query *QueryStringClause) Fields(fields ...string) *QueryStringClause {
+	query.kv["fields"] = fields
+	return query
+}
+
+// DefaultField sets the field queried when no field is given in the text
+func (query *QueryStringClause) DefaultField(field string) *QueryStringClause {
+	query.kv[DefaultField] = field
+	return query
+}
+
+// DefaultOperator sets the operator used between terms without an explicit operator (i.e. OR, AND)
+func (query *QueryStringClause) DefaultOperator(operator string) *QueryStringClause {
+	query.kv[DefaultOperator] = operator
+	return query
+}
+
+// Analyzer sets the analyzer used on the text
+func (query *QueryStringClause) Analyzer(analyzer string) *QueryStringClause {
+	query.kv[ANALYZER] = analyzer
+	return query
+}
+
+// Lenient ignores format based errors (e.g. a text on a numeric field)
+func (query *QueryStringClause) Lenient(lenient bool) *QueryStringClause {
+	query.kv[Lenient] = lenient
+	return query
+}
+
+// Add adds a parameter to this query (e.g. allow_leading_wildcard, phrase_slop)
+func (query *QueryStringClause) Add(name string, value interface{}) *QueryStringClause {
+	query.kv[name] = value
+	return query
+}
+
+// Boost sets the boost of this query
+func (query *QueryStringClause) Boost(boost float32) *QueryStringClause {
+	query.kv[Boost] = boost
+	return query
+}
+
+// SimpleQueryStringClause a structure representing the 'simple_query_string' query
+type SimpleQueryStringClause struct {
+	kv Dict
+}
+
+// SimpleQueryStringQuery creates a new 'simple_query_string' query of the given text, e.g. "fried eggs" +(eggplant | potato) -frittata
+func SimpleQueryStringQuery(text string) *SimpleQueryStringClause {
+	return &SimpleQueryStringClause{kv: Dict{"query": text}}
+}
+
+// Name returns the name of this query
+func (query *SimpleQueryStringClause) Name() string {
+	return SimpleQueryString
+}
+
+// KV returns the body of this query as a dictionary
+func (query *SimpleQueryStringClause) KV() Dict {
+	return query.kv
+}
+
+// String returns a string representation of this query
+func (query *SimpleQueryStringClause) String() string {
+	return String(Dict{query.Name(): query.kv})
+}
+
+// Fields sets the fields to query, with optional boosts (e.g. title^3)
+func (query *SimpleQueryStringClause) Fields(fields ...string) *SimpleQueryStringClause {
+	query.kv["fields"] = fields
+	return query
+}
+
+// DefaultOperator sets the operator used between terms without an explicit operator (i.e. OR, AND)
+func (query *SimpleQueryStringClause) DefaultOperator(operator string) *SimpleQueryStringClause {
+	query.kv[DefaultOperator] = operator
+	return query
+}
+
+// Analyzer sets the analyzer used on the text
+func (query *SimpleQueryStringClause) Analyzer(analyzer string) *SimpleQueryStringClause {
+	query.kv[ANALYZER] = analyzer
+	return query
+}
+
+// Flags sets the enabled operators (e.g. AND, OR, PREFIX, PHRASE, FUZZY, ALL, NONE)
+func (query *SimpleQueryStringClause) Flags(flags ...string) *SimpleQueryStringClause {
+	query.kv[Flags] = strings.Join(flags, "|")
+	return query
+}
+
+// Lenient ignores format based errors (e.g. a text on a numeric field)
+func (query *SimpleQueryStringClause) Lenient(lenient bool) *SimpleQueryStringClause {
+	query.kv[Lenient] = lenient
+	return query
+}
+
+// Boost sets the boost of this query
+func (query *SimpleQueryStringClause) Boost(boost float32) *SimpleQueryStringClause {
+	query.kv[Boost] = boost
+	return query
+}
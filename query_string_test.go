@@ -0,0 +1,99 @@
+package elastic
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// test for query_string and simple_query_string queries
+func TestQueryStringQuery(t *testing.T) {
+	actual := []string{
+		QueryStringQuery("title:(quick OR brown) AND fox").Fields("title^3", "body").DefaultOperator("AND").Analyzer("english").Lenient(true).String(),
+		QueryStringQuery("fox").DefaultField("title").Add("phrase_slop", 2).String(),
+		SimpleQueryStringQuery(`"fried eggs" +(eggplant | potato) -frittata`).Fields("title").Flags("OR", "AND", "PREFIX").DefaultOperator("and").String(),
+		// a valid text is kept as a query_string query
+		QueryStringQuery("title:fox").DefaultField("title").FallbackToSimple().String(),
+		// an invalid text falls back to a simple_query_string query
+		QueryStringQuery("(fox AND").DefaultField("title").Add("phrase_slop", 2).Lenient(true).FallbackToSimple().String(),
+	}
+	expected := []string{
+		`{"query_string":{"analyzer":"english","default_operator":"AND","fields":["title^3","body"],"lenient":true,"query":"title:(quick OR brown) AND fox"}}`,
+		`{"query_string":{"default_field":"title","phrase_slop":2,"query":"fox"}}`,
+		`{"simple_query_string":{"default_operator":"and","fields":["title"],"flags":"OR|AND|PREFIX","query":"\"fried eggs\" +(eggplant | potato) -frittata"}}`,
+		`{"query_string":{"default_field":"title","query":"title:fox"}}`,
+		`{"simple_query_string":{"fields":["title"],"lenient":true,"query":"(fox AND"}}`,
+	}
+	equals(t, actual, expected)
+}
+
+// test for escaping user input
+func TestEscapeQueryString(t *testing.T) {
+	actual := []string{
+		EscapeQueryString(`(1+1):2`),
+		EscapeQueryString(`C++ && "go" || rust! AND NOT ANDROID`),
+		EscapeQueryString(`a<b>c {x} [y] ^~*? \/ = -`),
+	}
+	expected := []string{
+		`\(1\+1\)\:2`,
+		`C\+\+ \&\& \"go\" \|\| rust\! and not ANDROID`,
+		`abc \{x\} \[y\] \^\~\*\? \\\/ \= \-`,
+	}
+	equals(t, actual, expected)
+	// escaped texts are always valid
+	for _, text := range []string{`(1+1):2`, `"unclosed`, `AND`, `trailing\`} {
+		if err := CheckQueryString(EscapeQueryString(text)); err != nil {
+			t.Error("Should be valid", text, err)
+		}
+	}
+}
+
+// test for checking the syntax of query strings
+func TestCheckQueryString(t *testing.T) {
+	for _, text := range []string{`fox`, `title:(quick OR brown) AND fox`, `price:[10 TO 20}`, `"a (b"`, `\(fox`, `+fox -dog`} {
+		if err := CheckQueryString(text); err != nil {
+			t.Error("Should be valid", text, err)
+		}
+	}
+	for _, text := range []string{`(fox`, `fox)`, `"fox`, `fox\`, `AND fox`, `fox OR`, `title:`, `fox NOT`, `(fox]`} {
+		if err := CheckQueryString(text); err == nil {
+			t.Error("Should not be valid", text)
+		}
+	}
+}
+
+// test for falling back to simple_query_string when Elasticsearch fails to parse a query_string text
+func TestQueryStringFallback(t *testing.T) {
+	bodies := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if strings.Contains(string(body), `"query_string"`) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"root_cause":[{"type":"query_shard_exception","reason":"Failed to parse query [a/b]"}],"type":"search_phase_execution_exception","reason":"all shards failed"},"status":400}`))
+			return
+		}
+		w.Write([]byte(`{"took":1,"hits":{"total":1,"hits":[]}}`))
+	}))
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	// the text passes CheckQueryString but is rejected by Elasticsearch
+	query := QueryStringQuery("a/b").DefaultField("title").FallbackToSimple()
+	result, err := client.Search("books", "").SetQuery(NewBool().AddMust(query)).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, []string{fmt.Sprint(result.Hits.Total, " ", len(bodies))}, []string{"1 2"})
+	equals(t, bodies, []string{
+		`{"query":{"bool":{"must":[{"query_string":{"default_field":"title","query":"a/b"}}]}}}`,
+		`{"query":{"bool":{"must":[{"simple_query_string":{"fields":["title"],"query":"a/b"}}]}}}`,
+	})
+	// without fallback the failure is returned
+	bodies = bodies[:0]
+	if _, err := client.Search("books", "").SetQuery(QueryStringQuery("a/b")).Do(); err == nil || len(bodies) != 1 {
+		t.Error("Should fail without fallback", err, bodies)
+	}
+}
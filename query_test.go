@@ -0,0 +1,40 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for leaf queries
+func TestLeafQueries(t *testing.T) {
+	actual := []string{
+		MatchQuery("title", "quick brown fox").Operator(And).Fuzziness("AUTO").String(),
+		MatchQuery("title", "quick brown fox").MinimumShouldMatch("75%").Boost(2).String(),
+		RangeQuery("date").Gte("2014-01-01").Lt("2014-02-01").Format("yyyy-MM-dd").String(),
+		TermQuery("productID", "XHDK-A-1293-#fJ3").String(),
+		TermsQuery("tag", "search", "nosql").Boost(1.5).String(),
+		IdsQuery("1", "4", "100").String(),
+		PrefixQuery("postcode", "W1").String(),
+		WildcardQuery("postcode", "W?F*HW").String(),
+		RegexpQuery("postcode", "W[0-9].+").Flags("ALL").String(),
+		ExistsQuery("title").String(),
+		MatchAllQuery().String(),
+		emptySearch().AddQuery(NewQuery("query").SetQuery(RangeQuery("price").Gte(20).Lte(40))).String(),
+		NewQuery("").SetQuery(NewBool().AddMust(MatchQuery("title", "fox")).AddMustNot(TermQuery("status", "deleted"))).String(),
+	}
+	expected := []string{
+		`{"match":{"title":{"fuzziness":"AUTO","operator":"and","query":"quick brown fox"}}}`,
+		`{"match":{"title":{"boost":2,"minimum_should_match":"75%","query":"quick brown fox"}}}`,
+		`{"range":{"date":{"format":"yyyy-MM-dd","gte":"2014-01-01","lt":"2014-02-01"}}}`,
+		`{"term":{"productID":{"value":"XHDK-A-1293-#fJ3"}}}`,
+		`{"terms":{"boost":1.5,"tag":["search","nosql"]}}`,
+		`{"ids":{"values":["1","4","100"]}}`,
+		`{"prefix":{"postcode":{"value":"W1"}}}`,
+		`{"wildcard":{"postcode":{"value":"W?F*HW"}}}`,
+		`{"regexp":{"postcode":{"flags":"ALL","value":"W[0-9].+"}}}`,
+		`{"exists":{"field":"title"}}`,
+		`{"match_all":{}}`,
+		`{"query":{"range":{"price":{"gte":20,"lte":40}}}}`,
+		`{"bool":{"must":[{"match":{"title":{"query":"fox"}}}],"must_not":[{"term":{"status":{"value":"deleted"}}}]}}`,
+	}
+	equals(t, actual, expected)
+}
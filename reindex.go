@@ -0,0 +1,231 @@
+package elastic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+const (
+	// REINDEX constant name of the Reindex API request
+	REINDEX = "reindex"
+	// DEST constant name of the destination part of a Reindex API query
+	DEST = "dest"
+	// REMOTE constant name of the remote cluster part of the source in a Reindex API query
+	REMOTE = "remote"
+	// OpType a parameter of the destination in a Reindex API query. Possible values: index (default), create (only missing documents are copied).
+	OpType = "op_type"
+	// Pipeline a parameter of the destination in a Reindex API query. It's the name of an ingest pipeline that transforms copied documents.
+	Pipeline = "pipeline"
+	// MaxDocs a parameter of a Reindex API query. It limits the number of documents to process.
+	MaxDocs = "max_docs"
+	// Conflicts a parameter of a Reindex API query. When set to 'proceed' version conflicts are counted instead of aborting the operation.
+	Conflicts = "conflicts"
+	// Slices a url param of the Reindex API. It is used to parallelize the operation, the value can be a number of slices or 'auto'.
+	Slices = "slices"
+	// WaitForCompletion a url param. When set to false the API returns a task that can be tracked instead of blocking until completion.
+	WaitForCompletion = "wait_for_completion"
+	// Painless the default scripting language of Elasticsearch
+	Painless = "painless"
+)
+
+// Reindex a request representing a Reindex API call, it copies documents from a source index into a destination one
+type Reindex struct {
+	client *Elasticsearch
+	parser *ReindexResultParser
+	url    string
+	params map[string]string
+	source Dict
+	dest   Dict
+	query  Dict
+}
+
+// Reindex creates a Reindex request
+func (client *Elasticsearch) Reindex() *Reindex {
+	url := client.request("", "", -1, REINDEX)
+	return newReindex(client, url)
+}
+
+// newReindex creates a new Reindex API call
+func newReindex(client *Elasticsearch, url string) *Reindex {
+	return &Reindex{
+		client: client,
+		parser: &ReindexResultParser{},
+		url:    url,
+		params: make(map[string]string),
+		source: make(Dict),
+		dest:   make(Dict),
+		query:  make(Dict),
+	}
+}
+
+// Source sets the indexes from which documents are copied
+func (reindex *Reindex) Source(indexes ...string) *Reindex {
+	if len(indexes) == 1 {
+		reindex.source[INDEX] = indexes[0]
+	} else {
+		reindex.source[INDEX] = indexes
+	}
+	return reindex
+}
+
+// SourceQuery restricts the copied documents to the ones matching the given query
+func (reindex *Reindex) SourceQuery(query Query) *Reindex {
+	reindex.source["query"] = Dict{query.Name(): query.KV()}
+	return reindex
+}
+
+// SourceSize sets the size of the batches read from the source index
+func (reindex *Reindex) SourceSize(size int) *Reindex {
+	reindex.source[Size] = size
+	return reindex
+}
+
+// SourceFields restricts the copied fields of the source documents
+func (reindex *Reindex) SourceFields(fields ...string) *Reindex {
+	reindex.source[SOURCE] = fields
+	return reindex
+}
+
+// Remote reads source documents from a remote cluster (e.g. http://otherhost:9200), username and password are optional
+func (reindex *Reindex) Remote(host, username, password string) *Reindex {
+	remote := Dict{"host": host}
+	if username != "" {
+		remote["username"] = username
+	}
+	if password != "" {
+		remote["password"] = password
+	}
+	reindex.source[REMOTE] = remote
+	return reindex
+}
+
+// Dest sets the index to which documents are copied
+func (reindex *Reindex) Dest(index string) *Reindex {
+	reindex.dest[INDEX] = index
+	return reindex
+}
+
+// OpType sets the operation type used to write into the destination index (e.g. create)
+func (reindex *Reindex) OpType(opType string) *Reindex {
+	reindex.dest[OpType] = opType
+	return reindex
+}
+
+// Pipeline sets the ingest pipeline that will process the copied documents
+func (reindex *Reindex) Pipeline(pipeline string) *Reindex {
+	reindex.dest[Pipeline] = pipeline
+	return reindex
+}
+
+// Script sets a painless script that transforms documents while they are copied
+func (reindex *Reindex) Script(source string, params Dict) *Reindex {
+	script := Dict{"lang": Painless, "source": source}
+	if len(params) > 0 {
+		script["params"] = params
+	}
+	reindex.query["script"] = script
+	return reindex
+}
+
+// MaxDocs sets the maximum number of documents to copy
+func (reindex *Reindex) MaxDocs(max int) *Reindex {
+	reindex.query[MaxDocs] = max
+	return reindex
+}
+
+// Conflicts sets how version conflicts are handled (e.g. proceed)
+func (reindex *Reindex) Conflicts(conflicts string) *Reindex {
+	reindex.query[Conflicts] = conflicts
+	return reindex
+}
+
+// Slices sets the number of slices used to parallelize the operation, 0 lets Elasticsearch choose ('auto')
+func (reindex *Reindex) Slices(slices int) *Reindex {
+	if slices <= 0 {
+		reindex.params[Slices] = "auto"
+	} else {
+		reindex.params[Slices] = strconv.Itoa(slices)
+	}
+	return reindex
+}
+
+// AddParam adds a url parameter/value, e.g. refresh, requests_per_second
+func (reindex *Reindex) AddParam(name, value string) *Reindex {
+	reindex.params[name] = value
+	return reindex
+}
+
+// Dict returns a dictionary representation of the body of this Reindex API call
+func (reindex *Reindex) Dict() Dict {
+	dict := make(Dict)
+	for k, v := range reindex.query {
+		dict[k] = v
+	}
+	dict["source"] = reindex.source
+	dict[DEST] = reindex.dest
+	return dict
+}
+
+// String returns a string representation of this Reindex API call
+func (reindex *Reindex) String() string {
+	return String(reindex.Dict())
+}
+
+// urlString constructs the url of this Reindex API call
+func (reindex *Reindex) urlString() string {
+	return urlString(reindex.url, reindex.params)
+}
+
+// Do submits the Reindex request and waits for its completion.
+// The result is returned with an error when some documents were not copied (see ReindexResult.Err).
+// POST /_reindex
+func (reindex *Reindex) Do() (*ReindexResult, error) {
+	return reindex.do(context.Background())
+}
+
+// do submits the Reindex request, it is aborted when the given context is done
+func (reindex *Reindex) do(ctx context.Context) (*ReindexResult, error) {
+	result, err := reindex.client.execute(ctx, "POST", reindex.urlString(), reindex.String(), reindex.parser)
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case ReindexResult:
+		return &res, res.Err()
+	case Failure:
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
+}
+
+// Start submits the Reindex request without waiting for its completion, it returns the identifier of the task running the operation
+// POST /_reindex?wait_for_completion=false
+func (reindex *Reindex) Start() (string, error) {
+	return reindex.start(context.Background())
+}
+
+// start submits the Reindex request without waiting for its completion, it is aborted when the given context is done
+func (reindex *Reindex) start(ctx context.Context) (string, error) {
+	reindex.params[WaitForCompletion] = "false"
+	result, err := reindex.do(ctx)
+	if err != nil {
+		return "", err
+	}
+	if result.Task == "" {
+		return "", errors.New("No task returned")
+	}
+	return result.Task, nil
+}
+
+// Err returns an error when the operation did not process all the documents, i.e. it has failures or it timed out
+func (result *ReindexResult) Err() error {
+	if len(result.Failures) > 0 {
+		return fmt.Errorf("%d failures, first one: %s", len(result.Failures), String(result.Failures[0]))
+	}
+	if result.TimedOut {
+		return errors.New("timed out")
+	}
+	return nil
+}
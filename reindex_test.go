@@ -0,0 +1,66 @@
+package elastic
+
+import (
+	"strings"
+	"testing"
+)
+
+// test for reindex queries
+func TestReindex(t *testing.T) {
+	actual := []string{
+		newReindex(nil, "").Source("twitter").Dest("new_twitter").String(),
+		newReindex(nil, "").Source("twitter", "blog").SourceQuery(NewTerm().Add("user", "kimchy")).Dest("all_together").OpType("create").Pipeline("some_ingest_pipeline").String(),
+		newReindex(nil, "").Source("twitter").Remote("http://otherhost:9200", "user", "pass").Dest("twitter").MaxDocs(100).Conflicts("proceed").String(),
+		newReindex(nil, "").Source("twitter").Dest("new_twitter").Script("ctx._source.likes++", Dict{"step": 1}).String(),
+	}
+	expected := []string{
+		`{"dest":{"index":"new_twitter"},"source":{"index":"twitter"}}`,
+		`{"dest":{"index":"all_together","op_type":"create","pipeline":"some_ingest_pipeline"},"source":{"index":["twitter","blog"],"query":{"term":{"user":"kimchy"}}}}`,
+		`{"conflicts":"proceed","dest":{"index":"twitter"},"max_docs":100,"source":{"index":"twitter","remote":{"host":"http://otherhost:9200","password":"pass","username":"user"}}}`,
+		`{"dest":{"index":"new_twitter"},"script":{"lang":"painless","params":{"step":1},"source":"ctx._source.likes++"},"source":{"index":"twitter"}}`,
+	}
+	equals(t, actual, expected)
+	// check url params
+	actualURL := []string{
+		newReindex(nil, "/_reindex").Slices(5).urlString(),
+		newReindex(nil, "/_reindex").Slices(0).urlString(),
+	}
+	expectedURL := []string{
+		"/_reindex?slices=5",
+		"/_reindex?slices=auto",
+	}
+	equals(t, actualURL, expectedURL)
+}
+
+// test for reindex result parser
+func TestReindexResultParser(t *testing.T) {
+	parser := &ReindexResultParser{}
+	input := []string{
+		`{"took":147,"timed_out":false,"total":120,"updated":0,"created":120,"deleted":0,"batches":1,"version_conflicts":0,"noops":0,"retries":{"bulk":0,"search":0},"throttled_millis":0,"requests_per_second":-1.0,"throttled_until_millis":0,"failures":[]}`,
+		`{"task":"oTUltX4IQMOUUVeiohTt8A:12345"}`,
+		`{"error":{"root_cause":[{"type":"index_not_found_exception","reason":"no such index","index":"twitter"}],"type":"index_not_found_exception","reason":"no such index","index":"twitter"},"status":404}`,
+	}
+	expected := []interface{}{
+		ReindexResult{Took: 147, Total: 120, Created: 120, Batches: 1, RequestsPerSecond: -1, Failures: []Dict{}},
+		ReindexResult{Task: "oTUltX4IQMOUUVeiohTt8A:12345"},
+		Failure{Err: Error{RootCause: []Dict{Dict{"type": "index_not_found_exception", "reason": "no such index", "index": "twitter"}}, Type: "index_not_found_exception", Reason: "no such index", Index: "twitter"}, Status: 404},
+	}
+	checkParsingResult(t, input, parser, expected)
+}
+
+// test for reindex failures, the documents that failed to be copied make the request fail
+func TestReindexFailures(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(map[string]string{
+		"POST /_reindex": `{"took":10,"timed_out":false,"total":2,"created":1,"batches":1,"failures":[{"index":"new_twitter","id":"2","cause":{"type":"mapper_parsing_exception","reason":"failed to parse [likes]"},"status":400}]}`,
+	}, &requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	result, err := client.Reindex().Source("twitter").Dest("new_twitter").Do()
+	if err == nil || result == nil || result.Created != 1 {
+		t.Error("Reindex should fail with its result", result, err)
+	}
+	if err := (&ReindexResult{TimedOut: true}).Err(); err == nil {
+		t.Error("A timed out reindex should fail")
+	}
+}
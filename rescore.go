@@ -0,0 +1,81 @@
+package elastic
+
+import (
+	"encoding/json"
+)
+
+const (
+	// QueryWeight a rescore parameter defining the weight of the score of the original query (1 by default)
+	QueryWeight = "query_weight"
+	// RescoreQueryWeight a rescore parameter defining the weight of the score of the rescore query (1 by default)
+	RescoreQueryWeight = "rescore_query_weight"
+)
+
+// Rescorer a structure representing the rescoring of the top hits of each shard with another (usually costlier) query
+type Rescorer struct {
+	windowSize int
+	rescore    Query
+	query      Dict
+}
+
+// NewRescorer creates a new rescorer with the given rescore query, it's rendered with the rescorer so later changes to the query are kept
+func NewRescorer(query Query) *Rescorer {
+	return &Rescorer{rescore: query, query: make(Dict)}
+}
+
+// WindowSize sets the number of top hits of each shard that are rescored (10 by default)
+func (rescorer *Rescorer) WindowSize(size int) *Rescorer {
+	rescorer.windowSize = size
+	return rescorer
+}
+
+// QueryWeight sets the weight of the score of the original query
+func (rescorer *Rescorer) QueryWeight(weight float32) *Rescorer {
+	rescorer.query[QueryWeight] = weight
+	return rescorer
+}
+
+// RescoreQueryWeight sets the weight of the score of the rescore query
+func (rescorer *Rescorer) RescoreQueryWeight(weight float32) *Rescorer {
+	rescorer.query[RescoreQueryWeight] = weight
+	return rescorer
+}
+
+// ScoreMode sets how the scores are combined (i.e. total, multiply, avg, max, min)
+func (rescorer *Rescorer) ScoreMode(mode string) *Rescorer {
+	rescorer.query[ScoreMode] = mode
+	return rescorer
+}
+
+// Dict returns the rescorer as a dictionary
+func (rescorer *Rescorer) Dict() Dict {
+	query := Dict{RescoreQuery: clause{query: rescorer.rescore, named: true}}
+	for k, v := range rescorer.query {
+		query[k] = v
+	}
+	dict := Dict{"query": query}
+	if rescorer.windowSize > 0 {
+		dict[WindowSize] = rescorer.windowSize
+	}
+	return dict
+}
+
+// MarshalJSON renders this rescorer, so that it can be kept as is in the body of a search request
+func (rescorer *Rescorer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rescorer.Dict())
+}
+
+// String returns a string representation of this rescorer
+func (rescorer *Rescorer) String() string {
+	return String(rescorer.Dict())
+}
+
+// AddRescorer appends rescorers to this search request, they are applied in the order they are added.
+// They are appended to the rescore already set (e.g. parsed or added with NewRescore), and rendered with the request
+// so later changes to them are kept.
+func (search *Search) AddRescorer(rescorers ...*Rescorer) *Search {
+	for _, rescorer := range rescorers {
+		search.query[RESCORE] = appendClause(search.query[RESCORE], rescorer)
+	}
+	return search
+}
@@ -0,0 +1,39 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for rescoring search results
+func TestRescorer(t *testing.T) {
+	title := NewQuery("title").Add("query", "quick brown fox").Add("slop", 50)
+	phrase := NewMatchPhrase().SetQuery(title)
+	actual := []string{
+		NewRescorer(phrase).String(),
+		NewRescorer(phrase).WindowSize(50).QueryWeight(0.7).RescoreQueryWeight(1.2).ScoreMode("multiply").String(),
+		emptySearch().AddRescorer(NewRescorer(phrase).WindowSize(100)).AddRescorer(NewRescorer(TermQuery("tag", "new")).WindowSize(10)).String(),
+	}
+	expected := []string{
+		`{"query":{"rescore_query":{"match_phrase":{"title":{"query":"quick brown fox","slop":50}}}}}`,
+		`{"query":{"query_weight":0.7,"rescore_query":{"match_phrase":{"title":{"query":"quick brown fox","slop":50}}},"rescore_query_weight":1.2,"score_mode":"multiply"},"window_size":50}`,
+		`{"rescore":[{"query":{"rescore_query":{"match_phrase":{"title":{"query":"quick brown fox","slop":50}}}},"window_size":100},{"query":{"rescore_query":{"term":{"tag":{"value":"new"}}}},"window_size":10}]}`,
+	}
+	equals(t, actual, expected)
+	// rescorers are appended to the existing rescore, and changes made after they were added are rendered
+	parsed, _ := ParseSearchBody([]byte(`{"rescore":[{"window_size":5,"query":{"rescore_query":{"match_all":{}}}}]}`))
+	rescorer := NewRescorer(phrase)
+	single := emptySearch().AddQuery(NewRescore().Add(WindowSize, 5)).AddRescorer(NewRescorer(TermQuery("tag", "new")))
+	actual = []string{
+		parsed.AddRescorer(rescorer).String(),
+		single.String(),
+	}
+	rescorer.WindowSize(20)
+	title.Add(SLOP, 10)
+	actual = append(actual, parsed.String())
+	expected = []string{
+		`{"rescore":[{"query":{"rescore_query":{"match_all":{}}},"window_size":5},{"query":{"rescore_query":{"match_phrase":{"title":{"query":"quick brown fox","slop":50}}}}}]}`,
+		`{"rescore":[{"window_size":5},{"query":{"rescore_query":{"term":{"tag":{"value":"new"}}}}}]}`,
+		`{"rescore":[{"query":{"rescore_query":{"match_all":{}}},"window_size":5},{"query":{"rescore_query":{"match_phrase":{"title":{"query":"quick brown fox","slop":10}}}},"window_size":20}]}`,
+	}
+	equals(t, actual, expected)
+}
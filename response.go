@@ -1,7 +1,8 @@
 package elastic
 
 import (
-//"encoding/json"
+	"encoding/json"
+	"fmt"
 )
 
 // Failure is a structure representing the Elasticsearch failure response
@@ -12,6 +13,11 @@ type Failure struct {
 	Status int   `json:"status"`
 }
 
+// Error returns a description of this failure, so that it can be returned as an error
+func (failure Failure) Error() string {
+	return fmt.Sprintf("%s: %s (status %d)", failure.Err.Type, failure.Err.Reason, failure.Status)
+}
+
 // Error is a structure representing the Elasticsearch error response
 type Error struct {
 	RootCause    []Dict `json:"root_cause"`
@@ -30,12 +36,10 @@ type Success struct {
 	Acknowledged bool `json:"acknowledged"`
 }
 
-// Unvalid is a structure representing an Elasticsearch unvalid response
-// e.g.: {"valid":false,"_shards":{"total":1,"successful":1,"failed":0},"explanations":[{"index":"gb","valid":false,"error":"org.elasticsearch.index.query.QueryParsingException: No query registered for [tweet]"}]}
-type Unvalid struct {
-	Valid       bool   `json:"valid"`
-	Shards      Dict   `json:"_shards"`
-	Explanation []Dict `json:"explanations"`
+// ShardMgmtResult is a structure representing an Elasticsearch shard management (e.g. refresh, flush) response
+// e.g.: {"_shards":{"total":10,"successful":5,"failed":0}}
+type ShardMgmtResult struct {
+	Shards Shard `json:"_shards"`
 }
 
 /////////////////////////////////// Search Query
@@ -56,36 +60,155 @@ type Hits struct {
 
 // SearchHits is a structure represennting the hitted document
 type SearchHits struct {
-	Index  string  `json:"_index"`
-	Type   string  `json:"_type"`
-	ID     string  `json:"_id"`
-	Score  float32 `json:"_score"`
-	Source Dict    `json:"_source"`
+	Index     string              `json:"_index"`
+	Type      string              `json:"_type"`
+	ID        string              `json:"_id"`
+	Score     float32             `json:"_score"`
+	Source    Dict                `json:"_source"`
+	Highlight map[string][]string `json:"highlight"`
+	// InnerHits the nested or parent/child documents that caused this hit, by name
+	InnerHits map[string]SearchResult `json:"inner_hits"`
+	// Nested the location of a nested inner hit in its root document
+	Nested *NestedIdentity `json:"_nested"`
+	// Fields the values of the fields requested out of the source (e.g. the value a hit is collapsed on)
+	Fields map[string][]interface{} `json:"fields"`
+}
+
+// NestedIdentity is a structure representing the location of a nested inner hit
+// e.g. {"field":"comments","offset":1}
+type NestedIdentity struct {
+	Field  string          `json:"field"`
+	Offset int             `json:"offset"`
+	Nested *NestedIdentity `json:"_nested"`
 }
 
 // ExplainResult Elasticsearch explain result
 // e.g. {"valid":true,"_shards":{"total":1,"successful":1,"failed":0},"explanations":[{"index":"my_index","valid":true,"explanation":"+((name:b name:br name:bro name:brow name:brown) (name:f name:fo)) #ConstantScore(+ConstantScore(_type:my_type))"}]}
+// e.g. {"valid":false,"error":"org.elasticsearch.common.ParsingException: no [query] registered for [rage]"}
 type ExplainResult struct {
 	Valid        bool          `json:"valid"`
 	Shards       Shard         `json:"_shards"`
 	Explanations []Explanation `json:"explanations"`
+	// Error the reason why the query is not valid, when explanations are not requested
+	Error string `json:"error"`
 }
 
 // Explanation the details of explanation
+// e.g. {"index":"gb","shard":0,"valid":false,"error":"org.elasticsearch.index.query.QueryParsingException: No query registered for [tweet]"}
 type Explanation struct {
 	Index       string `json:"index"`
+	Shard       *int   `json:"shard"`
 	Valid       bool   `json:"valid"`
 	Explanation string `json:"explanation"`
+	Error       string `json:"error"`
+}
+
+// ExplainDocResult is a structure representing the Elasticsearch explain result, i.e. why a document matches (or not) a query
+// e.g. {"_index":"my_index","_type":"my_type","_id":"1","matched":true,"explanation":{"value":1.2,"description":"sum of:","details":[{"value":1.2,"description":"weight(title:fox in 0) [PerFieldSimilarity], result of:","details":[]}]}}
+type ExplainDocResult struct {
+	Index       string            `json:"_index"`
+	Type        string            `json:"_type"`
+	ID          string            `json:"_id"`
+	Matched     bool              `json:"matched"`
+	Explanation *ScoreExplanation `json:"explanation"`
+}
+
+// ScoreExplanation is a structure representing how a score is computed, as a tree of sub scores
+type ScoreExplanation struct {
+	Value       float64            `json:"value"`
+	Description string             `json:"description"`
+	Details     []ScoreExplanation `json:"details"`
 }
 
 // SearchResult is a structure representing the Elastisearch search result
 // e.g. {"took":1,"timed_out":false,"_shards":{"total":5,"successful":5,"failed":0},"hits":{"total":0,"max_score":null,"hits":[]}}
 // e.g. {"took":3,"timed_out":false,"_shards":{"total":1,"successful":1,"failed":0},"hits":{"total":1,"max_score":0.50741017,"hits":[{"_index":"my_index","_type":"my_type","_id":"1","_score":0.50741017,"_source":{"name":"Brown foxes"}}]}}
 type SearchResult struct {
-	Took     int   `json:"took"`
-	TimedOut bool  `json:"timed_out"`
-	Shards   Shard `json:"_shards"`
-	Hits     Hits  `json:"hits"`
+	Took     int                     `json:"took"`
+	TimedOut bool                    `json:"timed_out"`
+	Shards   Shard                   `json:"_shards"`
+	Hits     Hits                    `json:"hits"`
+	Suggest  map[string][]Suggestion `json:"suggest"`
+	Profile  *Profile                `json:"profile"`
+	// Aggregations the raw results of the aggregations by name, see Aggs
+	Aggregations AggregationResults `json:"aggregations"`
+}
+
+// Profile is a structure representing the timing of the execution of a search request on each shard, when it's profiled
+// e.g. {"shards":[{"id":"[node1][my_index][0]","searches":[{"query":[{"type":"TermQuery","description":"title:fox","time_in_nanos":12345,"breakdown":{"score":4000,"create_weight":2000}}],"rewrite_time":500,"collector":[{"name":"SimpleTopScoreDocCollector","reason":"search_top_hits","time_in_nanos":3000}]}],"aggregations":[]}]}
+type Profile struct {
+	Shards []ShardProfile `json:"shards"`
+}
+
+// ShardProfile is a structure representing the timing of the execution of a search request on a shard
+type ShardProfile struct {
+	ID           string             `json:"id"`
+	Searches     []SearchProfile    `json:"searches"`
+	Aggregations []ProfileComponent `json:"aggregations"`
+}
+
+// SearchProfile is a structure representing the timing of the query tree and of the collectors of a search on a shard
+type SearchProfile struct {
+	Query       []ProfileComponent `json:"query"`
+	RewriteTime int64              `json:"rewrite_time"`
+	Collector   []CollectorProfile `json:"collector"`
+}
+
+// ProfileComponent is a structure representing the timing of a Lucene query or of an aggregation, and of its children
+type ProfileComponent struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description"`
+	TimeInNanos int64              `json:"time_in_nanos"`
+	Breakdown   map[string]int64   `json:"breakdown"`
+	Children    []ProfileComponent `json:"children"`
+}
+
+// CollectorProfile is a structure representing the timing of a Lucene collector, and of its children
+type CollectorProfile struct {
+	Name        string             `json:"name"`
+	Reason      string             `json:"reason"`
+	TimeInNanos int64              `json:"time_in_nanos"`
+	Children    []CollectorProfile `json:"children"`
+}
+
+// Suggestion is a structure representing the suggestions for a token of the text of a suggester
+// e.g. {"text":"tring","offset":0,"length":5,"options":[{"text":"string","score":0.8,"freq":1}]}
+type Suggestion struct {
+	Text    string             `json:"text"`
+	Offset  int                `json:"offset"`
+	Length  int                `json:"length"`
+	Options []SuggestionOption `json:"options"`
+}
+
+// SuggestionOption is a structure representing a suggested text.
+// Completion suggestions also hold the suggested document.
+type SuggestionOption struct {
+	Text         string  `json:"text"`
+	Score        float32 `json:"score"`
+	Freq         int     `json:"freq"`
+	Highlighted  string  `json:"highlighted"`
+	CollateMatch *bool   `json:"collate_match"`
+	Index        string  `json:"_index"`
+	Type         string  `json:"_type"`
+	ID           string  `json:"_id"`
+	Source       Dict    `json:"_source"`
+	Contexts     Dict    `json:"contexts"`
+}
+
+// UnmarshalJSON decodes a suggested text, the score of completion suggestions is named '_score'
+func (option *SuggestionOption) UnmarshalJSON(data []byte) error {
+	type suggestionOption SuggestionOption
+	decoded := struct {
+		*suggestionOption
+		DocScore *float32 `json:"_score"`
+	}{suggestionOption: (*suggestionOption)(option)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	if decoded.DocScore != nil {
+		option.Score = *decoded.DocScore
+	}
+	return nil
 }
 
 /////////////////////////////////// Analyze Query
@@ -129,25 +252,115 @@ type BulkResult struct {
 	Items  []InsertResult `json:"items"`
 }
 
+/////////////////////////////////// Reindex Query
+
+// ReindexResult is a structure representing the Elasticsearch reindex query result
+// e.g. {"took":147,"timed_out":false,"total":120,"updated":0,"created":120,"deleted":0,"batches":1,"version_conflicts":0,"noops":0,"retries":{"bulk":0,"search":0},"throttled_millis":0,"requests_per_second":-1.0,"throttled_until_millis":0,"failures":[]}
+// e.g. {"task":"oTUltX4IQMOUUVeiohTt8A:12345"} when the request does not wait for completion
+type ReindexResult struct {
+	Took              int     `json:"took"`
+	TimedOut          bool    `json:"timed_out"`
+	Total             int     `json:"total"`
+	Updated           int     `json:"updated"`
+	Created           int     `json:"created"`
+	Deleted           int     `json:"deleted"`
+	Batches           int     `json:"batches"`
+	VersionConflicts  int     `json:"version_conflicts"`
+	Noops             int     `json:"noops"`
+	Retries           Retries `json:"retries"`
+	ThrottledMillis   int     `json:"throttled_millis"`
+	RequestsPerSecond float32 `json:"requests_per_second"`
+	Failures          []Dict  `json:"failures"`
+	// Task the identifier of the task running the operation when it was not waited for
+	Task string `json:"task"`
+}
+
+// Retries is a structure representing the number of retries of a by-query operation (e.g. reindex)
+type Retries struct {
+	Bulk   int `json:"bulk"`
+	Search int `json:"search"`
+}
+
+/////////////////////////////////// Tasks Query
+
+// TaskListResult is a structure representing the Elasticsearch list tasks (or cancel tasks) query result
+// e.g. {"nodes":{"oTUltX4IQMOUUVeiohTt8A":{"name":"H5dfFeA","transport_address":"127.0.0.1:9300","host":"127.0.0.1","ip":"127.0.0.1:9300","tasks":{"oTUltX4IQMOUUVeiohTt8A:124":{"node":"oTUltX4IQMOUUVeiohTt8A","id":124,"type":"direct","action":"cluster:monitor/tasks/lists[n]","start_time_in_millis":1458585884904,"running_time_in_nanos":47402,"cancellable":false,"parent_task_id":"oTUltX4IQMOUUVeiohTt8A:123"}}}}}
+type TaskListResult struct {
+	Nodes        map[string]TaskNode `json:"nodes"`
+	NodeFailures []Dict              `json:"node_failures"`
+	TaskFailures []Dict              `json:"task_failures"`
+}
+
+// Tasks returns all the tasks of this result
+func (result *TaskListResult) Tasks() []TaskInfo {
+	tasks := []TaskInfo{}
+	for _, node := range result.Nodes {
+		for _, task := range node.Tasks {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+// TaskNode is a structure representing a node and the tasks it is running
+type TaskNode struct {
+	Name             string              `json:"name"`
+	TransportAddress string              `json:"transport_address"`
+	Host             string              `json:"host"`
+	IP               string              `json:"ip"`
+	Tasks            map[string]TaskInfo `json:"tasks"`
+}
+
+// TaskInfo is a structure representing a running task
+type TaskInfo struct {
+	Node               string     `json:"node"`
+	ID                 int64      `json:"id"`
+	Type               string     `json:"type"`
+	Action             string     `json:"action"`
+	Status             TaskStatus `json:"status"`
+	Description        string     `json:"description"`
+	StartTimeInMillis  int64      `json:"start_time_in_millis"`
+	RunningTimeInNanos int64      `json:"running_time_in_nanos"`
+	Cancellable        bool       `json:"cancellable"`
+	ParentTaskID       string     `json:"parent_task_id"`
+}
+
+// TaskStatus is a structure representing the progress of a by-query task (e.g. reindex, delete by query)
+type TaskStatus struct {
+	Total             int     `json:"total"`
+	Updated           int     `json:"updated"`
+	Created           int     `json:"created"`
+	Deleted           int     `json:"deleted"`
+	Batches           int     `json:"batches"`
+	VersionConflicts  int     `json:"version_conflicts"`
+	Noops             int     `json:"noops"`
+	Retries           Retries `json:"retries"`
+	ThrottledMillis   int     `json:"throttled_millis"`
+	RequestsPerSecond float32 `json:"requests_per_second"`
+	Canceled          string  `json:"canceled"`
+}
+
+// TaskResult is a structure representing the Elasticsearch get task query result
+// e.g. {"completed":true,"task":{"node":"oTUltX4IQMOUUVeiohTt8A","id":12345,"type":"transport","action":"indices:data/write/reindex","status":{"total":120,"updated":0,"created":120,"deleted":0,"batches":1},"description":"reindex from [twitter] to [new_twitter]","cancellable":true},"response":{"took":147,"timed_out":false,"total":120,"created":120,"batches":1,"failures":[]}}
+type TaskResult struct {
+	Completed bool     `json:"completed"`
+	Task      TaskInfo `json:"task"`
+	// Response the response of the operation once the task is completed (e.g. for reindex, update by query and delete by query)
+	Response *ReindexResult `json:"response"`
+	Error    *Error         `json:"error"`
+}
+
 /////////////////////////////////// Aggregation Query
 // AggregationResult is a structure representing the Elasticsearch aggregation query result
 // e.g. {"took":4,"timed_out":false,"_shards":{"total":5,"successful":5,"failed":0},"hits":{"total":7,"max_score":0.0,"hits":[]},"aggregations":{"colors":{"doc_count_error_upper_bound":0,"sum_other_doc_count":0,"buckets":[{"key":"blue","doc_count":1,"avg_price":{"value":15000.0}},{"key":"green","doc_count":2,"avg_price":{"value":21000.0}},{"key":"red","doc_count":4,"avg_price":{"value":32500.0}}]}}}
 // e.g. {"took":3,"timed_out":false,"_shards":{"total":5,"successful":5,"failed":0},"hits":{"total":7,"max_score":0.0,"hits":[]},"aggregations":{"distinct_colors":{"value":3}}}
+// The aggregations are navigated with SearchResult.Aggs, e.g. res.Aggs().Terms("colors").Buckets()[0].Aggs().Avg("avg_price").Value
 type AggregationResult struct {
 	SearchResult
-	Aggregations map[string]AggSubResult `json:"aggregations"`
-}
-
-// AggSubResult is a structure representing a sub result of the aggregation query result
-type AggSubResult struct {
-	DocCountErrorUpperBound int    `json:"doc_count_error_upper_bound"`
-	SumOtherDocCount        int    `json:"sum_other_doc_count"`
-	Buckets                 []Dict `json:"buckets"`
-	//Buckets                 []BucketResult `json:"buckets"`
 }
 
-type BucketResult struct {
-	Key      string `json:"key"`
-	DocCount int    `json:"doc_count"`
-	Dict     `json`
+// RenderTemplateResult is a structure representing the Elasticsearch render template query result
+// e.g. {"template_output":{"query":{"match":{"title":"fox"}},"size":10}}
+type RenderTemplateResult struct {
+	TemplateOutput json.RawMessage `json:"template_output"`
 }
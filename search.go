@@ -1,6 +1,10 @@
 package elastic
 
-import ()
+import (
+	"encoding/json"
+	"errors"
+	"log"
+)
 
 // Dict a dictionary with string keys and values of any type
 type Dict map[string]interface{}
@@ -112,6 +116,8 @@ type Search struct {
 	url    string
 	params map[string]string
 	query  Dict
+	// lint whether the queries are checked with Lint before the request is sent
+	lint bool
 }
 
 // Query defines an interfece of an object from an Elasticsearch query
@@ -120,6 +126,74 @@ type Query interface {
 	KV() Dict
 }
 
+// clause a sub query kept as a query in the body of its parent. It's rendered with the body of its parent,
+// so that the changes made to the sub query after it was added are kept and the tree of queries can be navigated.
+type clause struct {
+	query Query
+	// named whether the query is rendered with its name (e.g. {"match":{...}}) or as its body only
+	named bool
+}
+
+// MarshalJSON renders the sub query
+func (c clause) MarshalJSON() ([]byte, error) {
+	if c.named {
+		return json.Marshal(Dict{c.query.Name(): c.query.KV()})
+	}
+	return json.Marshal(c.query.KV())
+}
+
+// appendClause appends a clause to a value of a query body: nothing, a single clause or an array of clauses
+func appendClause(existing, value interface{}) []interface{} {
+	switch v := existing.(type) {
+	case nil:
+		return []interface{}{value}
+	case []interface{}:
+		return append(v, value)
+	case []Dict:
+		clauses := []interface{}{}
+		for _, dict := range v {
+			clauses = append(clauses, dict)
+		}
+		return append(clauses, value)
+	}
+	return []interface{}{existing, value}
+}
+
+// clauseQueries returns the sub queries of a value of a query body, values set as dictionaries (e.g. with Add) are not queries
+func clauseQueries(value interface{}) []Query {
+	queries := []Query{}
+	switch v := value.(type) {
+	case clause:
+		queries = append(queries, v.query)
+	case []interface{}:
+		for _, item := range v {
+			if c, ok := item.(clause); ok {
+				queries = append(queries, c.query)
+			}
+		}
+	}
+	return queries
+}
+
+// visitQueries calls visit on the sub queries kept in a value of a request body (see clause) and on their own sub queries
+func visitQueries(value interface{}, visit func(Query)) {
+	switch v := value.(type) {
+	case clause:
+		visit(v.query)
+		visitQueries(v.query.KV(), visit)
+	case aggClause:
+		visitQueries(v.agg.Dict(), visit)
+	case Dict:
+		for _, item := range v {
+			visitQueries(item, visit)
+		}
+	case []interface{}:
+		for _, item := range v {
+			visitQueries(item, visit)
+		}
+	}
+}
+
 // Object a general purpose query
 type Object struct {
 	name string
@@ -131,7 +205,9 @@ func (obj *Object) Name() string {
 	return obj.name
 }
 
-// KV returns the key-value store representing the body of this query
+// KV returns the key-value store representing the body of this query.
+// The sub queries added with AddQuery, SetQuery or AddQueries are stored as queries (not as Dict) and rendered with the body
+// when it's marshalled to JSON, use Clauses to get them.
 func (obj *Object) KV() Dict {
 	return obj.kv
 }
@@ -201,21 +277,6 @@ func (obj *Object) String() string {
 	return String(obj.KV())
 }
 
-// Explain creates an Explaination request, that will return explanation for why a document is returned by the query
-func (client *Elasticsearch) Explain(index, class string, id int64) *Search {
-	url := client.request(index, class, id, EXPLAIN)
-	return newSearch(client, url)
-}
-
-// Validate creates a Validation request
-func (client *Elasticsearch) Validate(index, class string, explain bool) *Search {
-	url := client.request(index, class, -1, VALIDATE) + "/query"
-	if explain {
-		url += "?" + EXPLAIN
-	}
-	return newSearch(client, url)
-}
-
 // Search creates a Search request
 func (client *Elasticsearch) Search(index, class string) *Search {
 	url := client.request(index, class, -1, SEARCH)
@@ -251,19 +312,35 @@ func (search *Search) AddQuery(query Query) *Search {
 	return search
 }
 
-// AddSource adds to _source (i.e. specify another field that should be extracted)
+// AddSource adds to _source (i.e. specify another field that should be extracted).
+// It's added to the included fields when _source was set with includes/excludes, and it replaces a disabled _source.
 func (search *Search) AddSource(source string) *Search {
-	var sources []string
-	if search.query[SOURCE] == nil {
-		sources = []string{}
-	} else {
-		sources = search.query[SOURCE].([]string)
+	switch current := search.query[SOURCE].(type) {
+	case Dict:
+		current[Includes] = appendSource(current[Includes], source)
+	case map[string]interface{}:
+		current[Includes] = appendSource(current[Includes], source)
+	default:
+		search.query[SOURCE] = appendSource(current, source)
 	}
-	sources = append(sources, source)
-	search.query[SOURCE] = sources
 	return search
 }
 
+// appendSource appends a field to a list of fields: nothing (or a disabled _source), a single field or an array of fields.
+// The list is copied, so that a list shared with another request (e.g. set with Add) is not modified.
+func appendSource(fields interface{}, source string) interface{} {
+	switch current := fields.(type) {
+	case []string:
+		return append(append([]string{}, current...), source)
+	case []interface{}:
+		// e.g. a parsed body
+		return append(append([]interface{}{}, current...), source)
+	case string:
+		return []string{current, source}
+	}
+	return []string{source}
+}
+
 // Add adds a query argument/value, e.g. size, from, etc.
 func (search *Search) Add(argument string, value interface{}) *Search {
 	search.query[argument] = value
@@ -284,15 +361,62 @@ func (search *Search) urlString() string {
 	return urlString(search.url, search.params)
 }
 
+// EnforceLint checks the queries of this search request with Lint before sending it.
+// The request is not sent if errors are found, warnings (e.g. deprecated queries) are only logged.
+func (search *Search) EnforceLint() *Search {
+	search.lint = true
+	return search
+}
+
+// checkLint returns the error issues found by Lint as an error, when enforced
+func (search *Search) checkLint() error {
+	if !search.lint {
+		return nil
+	}
+	errs := LintError{}
+	for _, issue := range Lint(search) {
+		if issue.Severity != SeverityError {
+			log.Println(issue)
+			continue
+		}
+		errs = append(errs, issue)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
 // Get submits request mappings between the json fields and how Elasticsearch store them
 // GET /:index/:type/_search
+// The errors, including the lint issues of a request enforcing lint (see EnforceLint), are only logged, use Do to get them.
 func (search *Search) Get() {
-	// construct the url
-	url := search.urlString()
-	// construct the body
-	query := search.String()
+	if _, err := search.Do(); err != nil {
+		log.Println(err)
+	}
+}
 
-	search.client.Execute("GET", url, query, search.parser)
+// Do submits this search request and returns the search result
+// GET /:index/:type/_search
+func (search *Search) Do() (*SearchResult, error) {
+	if err := search.checkLint(); err != nil {
+		return nil, err
+	}
+	result, err := search.client.Execute("GET", search.urlString(), search.String(), search.parser)
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case SearchResult:
+		return &res, nil
+	case Failure:
+		if isQueryParseFailure(res) && search.fallBackToSimple() {
+			// send it again with the query_string queries rejected by Elasticsearch rendered as simple_query_string
+			return search.Do()
+		}
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
 }
 
 // Add adds a query argument/value
@@ -307,42 +431,34 @@ func (obj *Object) AddMultiple(argument string, values ...interface{}) *Object {
 	return obj
 }
 
-// AddQueries adds multiple queries, under given `name`
+// AddQueries adds multiple queries, under given `name`. They are always rendered as an array, e.g. "queries":[{"match":{...}}]
 func (obj *Object) AddQueries(name string, queries ...Query) *Object {
 	for _, q := range queries {
-		parent := NewQuery(name)
-		parent.AddQuery(q)
-		obj.AddQuery(parent)
+		obj.kv[name] = appendClause(obj.kv[name], clause{query: q, named: true})
 	}
 	return obj
 }
 
-// AddQuery adds a sub query (e.g. a field query)
+// AddQuery appends a sub query under its name (e.g. a 'filter'), the queries added under the same name are always
+// rendered as an array in the order they were added. A single valued sub query (e.g. the 'query' of a compound query,
+// a field query) is set with SetQuery.
 func (obj *Object) AddQuery(query Query) *Object {
-	collection := obj.kv[query.Name()]
-	// check if query.Name exists, otherwise transform the map to array
-	if collection == nil {
-		// at first the collection is a map
-		collection = query.KV()
-	} else {
-		// when more items are added, then it becomes an array
-		dict := query.KV()
-		// check if it is a map
-		if _, ok := collection.(Dict); ok {
-			array := []Dict{} // transform previous map into array
-			for k, v := range collection.(Dict) {
-				d := make(Dict)
-				d[k] = v
-				array = append(array, d)
-			}
-			collection = array
-		}
-		collection = append(collection.([]Dict), dict)
-	}
-	obj.kv[query.Name()] = collection
+	obj.kv[query.Name()] = appendClause(obj.kv[query.Name()], clause{query: query})
+	return obj
+}
+
+// SetQuery sets the sub query under its name (e.g. the 'query' of a compound query, a field query), it's rendered as an object
+// and replaces the value previously set under this name.
+func (obj *Object) SetQuery(query Query) *Object {
+	obj.kv[query.Name()] = clause{query: query}
 	return obj
 }
 
+// Clauses returns the sub queries under the given name (e.g. query, filter, queries), in the order they were added
+func (obj *Object) Clauses(name string) []Query {
+	return clauseQueries(obj.kv[name])
+}
+
 // Bool represents a boolean clause, it is a complex clause that allows to combine other clauses as 'must' match, 'must_not' match, 'should' match.
 type Bool struct {
 	name string
@@ -354,7 +470,9 @@ func (b *Bool) Name() string {
 	return b.name
 }
 
-// KV returns the key-value store representing the body of this 'bool' query
+// KV returns the key-value store representing the body of this 'bool' query.
+// The clauses (e.g. must, filter) are stored as queries (not as Dict) and rendered with the body when it's marshalled to JSON,
+// use Clauses to get them.
 func (b *Bool) KV() Dict {
 	return b.kv
 }
@@ -383,37 +501,32 @@ func (b *Bool) AddShould(query Query) *Bool {
 	return b
 }
 
+// AddFilter adds a 'filter' clause to this 'bool' clause, it must match but does not contribute to the score
+func (b *Bool) AddFilter(query Query) *Bool {
+	b.add(Filter, query)
+	return b
+}
+
+// MinimumShouldMatch sets the number (e.g. 1) or percentage (e.g. 75%) of 'should' clauses that must match
+func (b *Bool) MinimumShouldMatch(minimum interface{}) *Bool {
+	b.kv[MinimumShouldMatch] = minimum
+	return b
+}
+
 // Add adds a parameter to this `bool` query
 func (b *Bool) Add(name string, value interface{}) *Bool {
 	b.kv[name] = value
 	return b
 }
 
-// add adds a clause
+// Clauses returns the sub queries of the given occurrence (i.e. must, must_not, should, filter), in the order they were added
+func (b *Bool) Clauses(occur string) []Query {
+	return clauseQueries(b.kv[occur])
+}
+
+// add appends a clause, clauses are always rendered as an array in the order they were added
 func (b *Bool) add(key string, query Query) {
-	collection := b.kv[key]
-	// check if query.Name exists, otherwise transform the map to array
-	if collection == nil {
-		// at first the collection is a map
-		collection = make(Dict)
-		collection.(Dict)[query.Name()] = query.KV()
-	} else {
-		// when more items are added, then it becomes an array
-		dict := make(Dict)
-		dict[query.Name()] = query.KV()
-		// check if it is a map
-		if _, ok := collection.(Dict); ok {
-			array := []Dict{} // transform previous map into array
-			for k, v := range collection.(Dict) {
-				d := make(Dict)
-				d[k] = v
-				array = append(array, d)
-			}
-			collection = array
-		}
-		collection = append(collection.([]Dict), dict)
-	}
-	b.kv[key] = collection
+	b.kv[key] = appendClause(b.kv[key], clause{query: query, named: true})
 }
 
 // NewTerms creates a new 'terms' filter, it is like 'term' but can match multiple values
@@ -482,3 +595,35 @@ func (boosting *BoostingQuery) AddNegative(name string, value interface{}) *Boos
 	boosting.negative[name] = value
 	return boosting
 }
+
+// SetPositive sets the query the documents must match
+func (boosting *BoostingQuery) SetPositive(query Query) *BoostingQuery {
+	boosting.positive = Dict{query.Name(): clause{query: query}}
+	return boosting
+}
+
+// SetNegative sets the query of the documents whose score is reduced by the negative boost
+func (boosting *BoostingQuery) SetNegative(query Query) *BoostingQuery {
+	boosting.negative = Dict{query.Name(): clause{query: query}}
+	return boosting
+}
+
+// Positive returns the positive query, if it was set with SetPositive or parsed
+func (boosting *BoostingQuery) Positive() Query {
+	for _, value := range boosting.positive {
+		if c, ok := value.(clause); ok {
+			return c.query
+		}
+	}
+	return nil
+}
+
+// Negative returns the negative query, if it was set with SetNegative or parsed
+func (boosting *BoostingQuery) Negative() Query {
+	for _, value := range boosting.negative {
+		if c, ok := value.(clause); ok {
+			return c.query
+		}
+	}
+	return nil
+}
@@ -17,7 +17,7 @@ func TestGeneral(t *testing.T) {
 	input := []string{
 		NewQuery("").String(),
 		NewQuery("").Add("argument", "value").String(),
-		NewQuery("").AddQuery(NewQuery("query").AddQuery(NewQuery("match_all"))).String(),
+		NewQuery("").SetQuery(NewQuery("query").SetQuery(NewQuery("match_all"))).String(),
 	}
 	// expected result
 	output := []string{
@@ -53,8 +53,8 @@ func TestSearchUrl(t *testing.T) {
 // test for search queries
 func TestSearch(t *testing.T) {
 	actual := []string{
-		emptySearch().AddParam("search_type", "scan").AddParam("scroll", "1m").AddQuery(NewQuery("query").AddQuery(NewQuery("range").AddQuery(NewQuery("data").Add("gte", "2014-01-01").Add("lt", "2014-02-01")))).Add("size", 1000).String(),
-		emptySearch().AddQuery(NewQuery("query").AddQuery(NewQuery("match_all"))).AddSource("title").AddSource("created").String(),
+		emptySearch().AddParam("search_type", "scan").AddParam("scroll", "1m").AddQuery(NewQuery("query").SetQuery(NewQuery("range").SetQuery(NewQuery("data").Add("gte", "2014-01-01").Add("lt", "2014-02-01")))).Add("size", 1000).String(),
+		emptySearch().AddQuery(NewQuery("query").SetQuery(NewQuery("match_all"))).AddSource("title").AddSource("created").String(),
 	}
 	expected := []string{
 		`{"query":{"range":{"data":{"gte":"2014-01-01","lt":"2014-02-01"}}},"size":1000}`,
@@ -66,7 +66,7 @@ func TestSearch(t *testing.T) {
 // test for query clauses
 func TestQuery(t *testing.T) {
 	actual := []string{
-		newQuery().AddQuery(NewQuery("dis_max").AddQueries("queries", NewQuery("match").Add("title", "Brown fox"), NewQuery("match").Add("body", "Brown fox"))).String(),
+		newQuery().SetQuery(NewQuery("dis_max").AddQueries("queries", NewQuery("match").Add("title", "Brown fox"), NewQuery("match").Add("body", "Brown fox"))).String(),
 	}
 	expected := []string{
 		`{"dis_max":{"queries":[{"match":{"title":"Brown fox"}},{"match":{"body":"Brown fox"}}]}}`,
@@ -78,25 +78,57 @@ func TestQuery(t *testing.T) {
 // test for bool clauses
 func TestBool(t *testing.T) {
 	input := []string{
-		NewQuery("").AddQuery(NewBool().AddMust(NewQuery("match").Add("tweet", "elasticsearch"))).String(),
-		NewQuery("").AddQuery(NewBool().AddMust(NewQuery("match_all")).AddMustNot(NewQuery("match")).AddShould(NewQuery("match"))).String(),
-		NewQuery("").AddQuery(NewBool().AddShould(NewTerm().Add("price", 20)).AddShould(NewTerm().Add("productID", "XHDK-A-1293-#fJ3")).AddShould(NewTerm().Add("category", "smartphone"))).String(),
+		NewQuery("").SetQuery(NewBool().AddMust(NewQuery("match").Add("tweet", "elasticsearch"))).String(),
+		NewQuery("").SetQuery(NewBool().AddMust(NewQuery("match_all")).AddMustNot(NewQuery("match")).AddShould(NewQuery("match"))).String(),
+		NewQuery("").SetQuery(NewBool().AddShould(NewTerm().Add("price", 20)).AddShould(NewTerm().Add("productID", "XHDK-A-1293-#fJ3")).AddShould(NewTerm().Add("category", "smartphone"))).String(),
 	}
 	output := []string{
-		`{"bool":{"must":{"match":{"tweet":"elasticsearch"}}}}`,
-		`{"bool":{"must":{"match_all":{}},"must_not":{"match":{}},"should":{"match":{}}}}`,
+		`{"bool":{"must":[{"match":{"tweet":"elasticsearch"}}]}}`,
+		`{"bool":{"must":[{"match_all":{}}],"must_not":[{"match":{}}],"should":[{"match":{}}]}}`,
 		`{"bool":{"should":[{"term":{"price":20}},{"term":{"productID":"XHDK-A-1293-#fJ3"}},{"term":{"category":"smartphone"}}]}}`,
 	}
 	equals(t, input, output)
+	// filter clauses and minimum_should_match
+	actual := []string{
+		NewQuery("").SetQuery(NewBool().AddFilter(TermQuery("status", "published")).AddShould(MatchQuery("title", "fox")).AddShould(MatchQuery("body", "fox")).MinimumShouldMatch(1)).String(),
+	}
+	expected := []string{
+		`{"bool":{"filter":[{"term":{"status":{"value":"published"}}}],"minimum_should_match":1,"should":[{"match":{"title":{"query":"fox"}}},{"match":{"body":{"query":"fox"}}}]}}`,
+	}
+	equals(t, actual, expected)
+}
+
+// test that repeated clauses are rendered in the order they were added
+func TestClausesOrder(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		actual := []string{
+			NewQuery("").AddQuery(NewQuery("filter").Add("a", 1).Add("b", 2).Add("c", 3)).AddQuery(NewQuery("filter").Add("d", 4)).String(),
+			NewQuery("").AddQuery(NewQuery("filter").Add("a", 1)).String(),
+			NewQuery("").SetQuery(NewQuery("dis_max").AddQueries("queries", NewMatch().Add("title", "fox"))).String(),
+			NewQuery("").SetQuery(NewBool().AddShould(TermQuery("z", 1)).AddShould(TermQuery("a", 2)).AddShould(TermQuery("m", 3))).String(),
+		}
+		expected := []string{
+			`{"filter":[{"a":1,"b":2,"c":3},{"d":4}]}`,
+			`{"filter":[{"a":1}]}`,
+			`{"dis_max":{"queries":[{"match":{"title":"fox"}}]}}`,
+			`{"bool":{"should":[{"term":{"z":{"value":1}}},{"term":{"a":{"value":2}}},{"term":{"m":{"value":3}}}]}}`,
+		}
+		equals(t, actual, expected)
+	}
+	// clauses set or replaced with Add are appended to, SetQuery replaces them
+	replaced := NewQuery("").AddQuery(NewQuery("filter").Add("a", 1)).AddQuery(NewQuery("filter").Add("b", 2))
+	replaced.Add("filter", Dict{"c": 3}).AddQuery(NewQuery("filter").Add("d", 4))
+	set := NewQuery("").AddQuery(NewQuery("filter").Add("a", 1)).SetQuery(NewQuery("filter").Add("b", 2))
+	equals(t, []string{replaced.String(), set.String()}, []string{`{"filter":[{"c":3},{"d":4}]}`, `{"filter":{"b":2}}`})
 }
 
 // test for 'term', 'terms' and 'exists' filters
 func TestFilters(t *testing.T) {
 	actual := []string{
-		NewQuery("").AddQuery(NewTerm().Add("age", 26)).String(),
-		NewQuery("").AddQuery(NewTerms().AddMultiple("tag", "search", "full_text", "nosql")).String(),
-		NewQuery("").AddQuery(NewTerms().AddMultiple("price", 20, 30)).String(),
-		NewQuery("").AddQuery(NewExists().Add("field", "title")).String(),
+		NewQuery("").SetQuery(NewTerm().Add("age", 26)).String(),
+		NewQuery("").SetQuery(NewTerms().AddMultiple("tag", "search", "full_text", "nosql")).String(),
+		NewQuery("").SetQuery(NewTerms().AddMultiple("price", 20, 30)).String(),
+		NewQuery("").SetQuery(NewExists().Add("field", "title")).String(),
 	}
 	expected := []string{
 		`{"term":{"age":26}}`,
@@ -1,8 +1,7 @@
 package elastic
 
 import (
-	"fmt"
-	"io/ioutil"
+	"context"
 	"log"
 )
 
@@ -17,31 +16,40 @@ const (
 
 // ShardMgmtOp a structure for creating shard management operations
 type ShardMgmtOp struct {
+	client *Elasticsearch
+	parser *ShardMgmtResultParser
 	url    string
 	params map[string]string
 }
 
-func newShardMgmtOp(operation string) *ShardMgmtOp {
-	return &ShardMgmtOp{url: operation, params: make(map[string]string)}
+func newShardMgmtOp(url string) *ShardMgmtOp {
+	return &ShardMgmtOp{
+		parser: &ShardMgmtResultParser{},
+		url:    url,
+		params: make(map[string]string),
+	}
 }
 
 // Refresh create a refresh API call in order to force recently added document to be visible to search calls
 func (client *Elasticsearch) Refresh(index string) *ShardMgmtOp {
-	url := client.request(index, "", -1, REFRESH)
-	return &ShardMgmtOp{url: url}
+	op := newShardMgmtOp(client.request(index, "", -1, REFRESH))
+	op.client = client
+	return op
 }
 
 // Flush creates a flush API call in order to force commit and trauncating the 'translog'
 // See, chapter 11. Inside a shard (Elasticsearch Definitive Guide)
 func (client *Elasticsearch) Flush(index string) *ShardMgmtOp {
-	url := client.request(index, "", -1, FLUSH)
-	return &ShardMgmtOp{url: url, params: make(map[string]string)}
+	op := newShardMgmtOp(client.request(index, "", -1, FLUSH))
+	op.client = client
+	return op
 }
 
 // Optimize create an Optimize API call in order to force mering shards into a number of segments
 func (client *Elasticsearch) Optimize(index string) *ShardMgmtOp {
-	url := client.request(index, "", -1, OPTIMIZE)
-	return &ShardMgmtOp{url: url, params: make(map[string]string)}
+	op := newShardMgmtOp(client.request(index, "", -1, OPTIMIZE))
+	op.client = client
+	return op
 }
 
 // AddParam adds a query parameter to ths Flush API url (e.g. wait_for_ongoing), or Optmize API (e.g. max_num_segment to 1)
@@ -58,14 +66,13 @@ func (op *ShardMgmtOp) urlString() string {
 // Post submit a shard managemnt request
 // POST /:index/_refresh
 func (op *ShardMgmtOp) Post() {
-	url := op.urlString()
-	log.Println("POST", url)
-	reader, err := exec("POST", op.url, nil)
-	if err != nil {
+	if err := op.post(context.Background()); err != nil {
 		log.Println(err)
-		return
-	}
-	if data, err := ioutil.ReadAll(reader); err == nil {
-		fmt.Println(string(data))
 	}
 }
+
+// post submits the shard management request, it returns an error if the operation failed
+func (op *ShardMgmtOp) post(ctx context.Context) error {
+	url := op.urlString()
+	return checkResult(op.client.execute(ctx, "POST", url, "", op.parser))
+}
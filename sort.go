@@ -0,0 +1,181 @@
+package elastic
+
+import ()
+
+const (
+	// SORT constant name of the sort parameter of a Search API query
+	SORT = "sort"
+	// TrackScores a boolean parameter of a Search API query. It forces the calculation of scores when sorting on a field.
+	TrackScores = "track_scores"
+	// Asc ascending sort order
+	Asc = "asc"
+	// Desc descending sort order
+	Desc = "desc"
+	// Missing a sort parameter that defines how documents missing the field are sorted. Possible values: _last, _first or a custom value.
+	Missing = "missing"
+	// UnmappedType a sort parameter that defines the type used to sort on indexes where the field is not mapped.
+	UnmappedType = "unmapped_type"
+	// Mode a sort parameter that defines which value of a multi-valued field is used. Possible values: min, max, sum, avg, median.
+	Mode = "mode"
+	// Nested a sort parameter that defines the nested object containing the field.
+	Nested = "nested"
+	// Path the path of a nested object
+	Path = "path"
+	// Unit a parameter of '_geo_distance' sort defining the unit of distances. Example of values: m, km, mi.
+	Unit = "unit"
+	// DistanceType a parameter of '_geo_distance' sort defining how distances are computed. Possible values: arc (default), plane (faster).
+	DistanceType = "distance_type"
+	// ScoreField name of the pseudo field holding the document score
+	ScoreField = "_score"
+	// GeoDistance name of the sort by distance to geo points
+	GeoDistance = "_geo_distance"
+	// ScriptSort name of the sort on values calculated by a script
+	ScriptSort = "_script"
+)
+
+// Sort a structure representing a sort key of a Search API query
+type Sort struct {
+	name string
+	kv   Dict
+}
+
+// NewSort creates a new sort on the given field
+func NewSort(field string) *Sort {
+	return &Sort{name: field, kv: make(Dict)}
+}
+
+// NewScoreSort creates a new sort on the document score
+func NewScoreSort() *Sort {
+	return NewSort(ScoreField)
+}
+
+// NewGeoDistanceSort creates a new sort on the distance between the given field and the given points
+func NewGeoDistanceSort(field string, points ...interface{}) *Sort {
+	sort := NewSort(GeoDistance)
+	if len(points) == 1 {
+		sort.kv[field] = points[0]
+	} else {
+		sort.kv[field] = points
+	}
+	return sort
+}
+
+// NewScriptSort creates a new sort on the values calculated by the given painless script, valueType is either number or string
+func NewScriptSort(source, valueType string) *Sort {
+	sort := NewSort(ScriptSort)
+	sort.kv[TYPE] = valueType
+	sort.kv["script"] = Dict{"lang": Painless, "source": source}
+	return sort
+}
+
+// Order sets the sort order (i.e. asc or desc)
+func (sort *Sort) Order(order string) *Sort {
+	sort.kv[Order] = order
+	return sort
+}
+
+// Missing sets how documents missing the field are sorted (e.g. _last, _first)
+func (sort *Sort) Missing(value interface{}) *Sort {
+	sort.kv[Missing] = value
+	return sort
+}
+
+// UnmappedType sets the type used to sort on indexes where the field is not mapped (e.g. long)
+func (sort *Sort) UnmappedType(fieldType string) *Sort {
+	sort.kv[UnmappedType] = fieldType
+	return sort
+}
+
+// Mode sets which value of a multi-valued field is used for sorting (e.g. min, max, avg)
+func (sort *Sort) Mode(mode string) *Sort {
+	sort.kv[Mode] = mode
+	return sort
+}
+
+// NestedPath sets the path of the nested object containing the sort field
+func (sort *Sort) NestedPath(path string) *Sort {
+	sort.nested()[Path] = path
+	return sort
+}
+
+// NestedFilter restricts the nested objects taken into account when sorting
+func (sort *Sort) NestedFilter(query Query) *Sort {
+	sort.nested()[FILTER] = Dict{query.Name(): query.KV()}
+	return sort
+}
+
+// nested returns the nested object of this sort
+func (sort *Sort) nested() Dict {
+	if sort.kv[Nested] == nil {
+		sort.kv[Nested] = make(Dict)
+	}
+	return sort.kv[Nested].(Dict)
+}
+
+// Unit sets the unit of distances of a '_geo_distance' sort (e.g. km)
+func (sort *Sort) Unit(unit string) *Sort {
+	sort.kv[Unit] = unit
+	return sort
+}
+
+// DistanceType sets how distances are computed in a '_geo_distance' sort (i.e. arc or plane)
+func (sort *Sort) DistanceType(distanceType string) *Sort {
+	sort.kv[DistanceType] = distanceType
+	return sort
+}
+
+// Add adds a sort parameter
+func (sort *Sort) Add(name string, value interface{}) *Sort {
+	sort.kv[name] = value
+	return sort
+}
+
+// value returns the representation of this sort key in a query, a plain field name if no parameter is set
+func (sort *Sort) value() interface{} {
+	if len(sort.kv) == 0 {
+		return sort.name
+	}
+	return Dict{sort.name: sort.kv}
+}
+
+// String returns a string representation of this sort key
+func (sort *Sort) String() string {
+	return String(sort.value())
+}
+
+// appendSorts appends the given sort keys to a list of sort keys, the sort keys set otherwise (e.g. parsed, or with Add)
+// are kept: a single sort key (e.g. a field name or a dictionary) is turned into a list
+func appendSorts(list interface{}, sorts ...*Sort) []interface{} {
+	var values []interface{}
+	switch v := list.(type) {
+	case nil:
+	case []interface{}:
+		values = v
+	case []Dict:
+		for _, value := range v {
+			values = append(values, value)
+		}
+	case []string:
+		for _, value := range v {
+			values = append(values, value)
+		}
+	default:
+		values = []interface{}{v}
+	}
+	for _, sort := range sorts {
+		values = append(values, sort.value())
+	}
+	return values
+}
+
+// AddSort appends sort keys to this search request, documents are sorted by the keys in the order they are added
+func (search *Search) AddSort(sorts ...*Sort) *Search {
+	search.query[SORT] = appendSorts(search.query[SORT], sorts...)
+	return search
+}
+
+// TrackScores forces the calculation of scores when sorting on a field
+func (search *Search) TrackScores(track bool) *Search {
+	search.query[TrackScores] = track
+	return search
+}
@@ -0,0 +1,48 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for sort keys
+func TestSort(t *testing.T) {
+	actual := []string{
+		NewSort("date").String(),
+		NewSort("date").Order(Desc).String(),
+		NewSort("price").Order(Asc).Missing("_last").UnmappedType("long").Mode("avg").String(),
+		NewSort("offer.price").Mode("min").NestedPath("offer").NestedFilter(NewTerm().Add("offer.color", "blue")).String(),
+		NewGeoDistanceSort("location", Dict{"lat": 40.715, "lon": -73.998}).Order(Asc).Unit("km").DistanceType("plane").String(),
+		NewScriptSort("doc['price'].value * params.factor", "number").Order(Desc).String(),
+	}
+	expected := []string{
+		`"date"`,
+		`{"date":{"order":"desc"}}`,
+		`{"price":{"missing":"_last","mode":"avg","order":"asc","unmapped_type":"long"}}`,
+		`{"offer.price":{"mode":"min","nested":{"filter":{"term":{"offer.color":"blue"}},"path":"offer"}}}`,
+		`{"_geo_distance":{"distance_type":"plane","location":{"lat":40.715,"lon":-73.998},"order":"asc","unit":"km"}}`,
+		`{"_script":{"order":"desc","script":{"lang":"painless","source":"doc['price'].value * params.factor"},"type":"number"}}`,
+	}
+	equals(t, actual, expected)
+}
+
+// test for sorting search results
+func TestSearchSort(t *testing.T) {
+	actual := []string{
+		emptySearch().AddSort(NewSort("date").Order(Desc)).AddSort(NewScoreSort()).String(),
+		emptySearch().AddSort(NewSort("date").Order(Desc), NewSort("_id")).TrackScores(true).String(),
+		// sort keys set otherwise are kept
+		emptySearch().Add(SORT, Dict{"date": "desc"}).AddSort(NewSort("_id")).String(),
+		emptySearch().Add(SORT, "date").AddSort(NewSort("_id")).String(),
+		emptySearch().Add(SORT, []Dict{{"date": "desc"}}).AddSort(NewSort("_id")).String(),
+		emptySearch().Add(SORT, []string{"date", "price"}).AddSort(NewSort("_id")).String(),
+	}
+	expected := []string{
+		`{"sort":[{"date":{"order":"desc"}},"_score"]}`,
+		`{"sort":[{"date":{"order":"desc"}},"_id"],"track_scores":true}`,
+		`{"sort":[{"date":"desc"},"_id"]}`,
+		`{"sort":["date","_id"]}`,
+		`{"sort":[{"date":"desc"},"_id"]}`,
+		`{"sort":["date","price","_id"]}`,
+	}
+	equals(t, actual, expected)
+}
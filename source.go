@@ -0,0 +1,120 @@
+package elastic
+
+import ()
+
+const (
+	// Includes a source filtering parameter listing the fields (or wildcard patterns) to return
+	Includes = "includes"
+	// Excludes a source filtering parameter listing the fields (or wildcard patterns) not to return
+	Excludes = "excludes"
+	// DocvalueFields a parameter of Search API returning the doc values of fields, in SearchHits.Fields
+	DocvalueFields = "docvalue_fields"
+	// StoredFields a parameter of Search API returning the stored fields, in SearchHits.Fields
+	StoredFields = "stored_fields"
+	// ScriptFields a parameter of Search API returning values computed by scripts, in SearchHits.Fields
+	ScriptFields = "script_fields"
+	// FIELDS a parameter of Search API returning the values of fields as they are mapped, in SearchHits.Fields
+	FIELDS = "fields"
+	// NoStoredFields the value of 'stored_fields' disabling the stored fields (including _id and _source)
+	NoStoredFields = "_none_"
+)
+
+// SourceFilter a structure representing which fields of the source of hits are returned
+type SourceFilter struct {
+	includes []string
+	excludes []string
+	disabled bool
+}
+
+// NewSourceFilter creates a new source filter, by default the whole source is returned
+func NewSourceFilter() *SourceFilter {
+	return &SourceFilter{includes: []string{}, excludes: []string{}}
+}
+
+// NoSource creates a source filter that disables the source of hits
+func NoSource() *SourceFilter {
+	return &SourceFilter{disabled: true}
+}
+
+// Include adds fields to return, wildcards are accepted (e.g. obj.*)
+func (filter *SourceFilter) Include(patterns ...string) *SourceFilter {
+	filter.includes = append(filter.includes, patterns...)
+	return filter
+}
+
+// Exclude adds fields not to return, wildcards are accepted (e.g. *.description)
+func (filter *SourceFilter) Exclude(patterns ...string) *SourceFilter {
+	filter.excludes = append(filter.excludes, patterns...)
+	return filter
+}
+
+// Value returns the value of the _source parameter: false when disabled, the list of included fields when nothing is excluded,
+// otherwise the includes and excludes
+func (filter *SourceFilter) Value() interface{} {
+	if filter.disabled {
+		return false
+	}
+	// the lists are copied, so that changes made to the value (e.g. with Search.AddSource) do not change this filter
+	includes := append([]string{}, filter.includes...)
+	if len(filter.excludes) == 0 {
+		return includes
+	}
+	dict := Dict{Excludes: append([]string{}, filter.excludes...)}
+	if len(includes) > 0 {
+		dict[Includes] = includes
+	}
+	return dict
+}
+
+// String returns a string representation of this source filter
+func (filter *SourceFilter) String() string {
+	return String(filter.Value())
+}
+
+// Source sets which fields of the source of hits are returned
+func (search *Search) Source(filter *SourceFilter) *Search {
+	search.query[SOURCE] = filter.Value()
+	return search
+}
+
+// AddDocvalueField adds a field whose doc values are returned, with an optional format (e.g. epoch_millis for dates)
+func (search *Search) AddDocvalueField(field, format string) *Search {
+	search.query[DocvalueFields] = appendField(search.query[DocvalueFields], field, format)
+	return search
+}
+
+// AddField adds a field whose values are returned as they are mapped (e.g. with multi-fields and runtime fields), with an optional format
+func (search *Search) AddField(field, format string) *Search {
+	search.query[FIELDS] = appendField(search.query[FIELDS], field, format)
+	return search
+}
+
+// StoredFields sets the stored fields to return (i.e. fields mapped with 'store' true), NoStoredFields disables them
+func (search *Search) StoredFields(fields ...string) *Search {
+	search.query[StoredFields] = fields
+	return search
+}
+
+// AddScriptField adds a field computed by a painless script for each hit, the params can be nil
+func (search *Search) AddScriptField(name, source string, params Dict) *Search {
+	script := Dict{"lang": Painless, "source": source}
+	if params != nil {
+		script[PARAMS] = params
+	}
+	fields, ok := search.query[ScriptFields].(Dict)
+	if !ok {
+		fields = make(Dict)
+	}
+	fields[name] = Dict{"script": script}
+	search.query[ScriptFields] = fields
+	return search
+}
+
+// appendField appends a field to a list of fields, it's rendered as an object when it has a format
+func appendField(list interface{}, field, format string) []interface{} {
+	fields, _ := list.([]interface{})
+	if format == "" {
+		return append(fields, field)
+	}
+	return append(fields, Dict{Field: field, Format: format})
+}
@@ -0,0 +1,75 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for source filtering
+func TestSourceFilter(t *testing.T) {
+	actual := []string{
+		NewSourceFilter().Include("title", "obj.*").String(),
+		NewSourceFilter().Include("obj.*").Exclude("*.description").String(),
+		NewSourceFilter().Exclude("content").String(),
+		NoSource().String(),
+		emptySearch().Source(NoSource()).String(),
+		// AddSource does not panic when _source was set differently
+		emptySearch().Source(NoSource()).AddSource("title").String(),
+		emptySearch().Add(SOURCE, "title").AddSource("date").String(),
+		emptySearch().Source(NewSourceFilter().Exclude("content")).AddSource("title").String(),
+	}
+	expected := []string{
+		`["title","obj.*"]`,
+		`{"excludes":["*.description"],"includes":["obj.*"]}`,
+		`{"excludes":["content"]}`,
+		`false`,
+		`{"_source":false}`,
+		`{"_source":["title"]}`,
+		`{"_source":["title","date"]}`,
+		`{"_source":{"excludes":["content"],"includes":["title"]}}`,
+	}
+	equals(t, actual, expected)
+	// the includes of a parsed body are kept
+	parsed, err := ParseSearchBody([]byte(`{"_source":{"excludes":["content"],"includes":["title","obj.*"]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, []string{parsed.AddSource("date").String()}, []string{`{"_source":{"excludes":["content"],"includes":["title","obj.*","date"]}}`})
+	// includes of a plain map, and a filter shared by two requests
+	plain := emptySearch().Add(SOURCE, map[string]interface{}{Excludes: []string{"content"}}).AddSource("title")
+	shared := NewSourceFilter().Include("title", "date")
+	first := emptySearch().Source(shared).AddSource("price")
+	second := emptySearch().Source(shared).AddSource("tags")
+	equals(t, []string{plain.String(), first.String(), second.String(), shared.String()}, []string{
+		`{"_source":{"excludes":["content"],"includes":["title"]}}`,
+		`{"_source":["title","date","price"]}`,
+		`{"_source":["title","date","tags"]}`,
+		`["title","date"]`,
+	})
+}
+
+// test for requesting fields out of the source
+func TestSearchFields(t *testing.T) {
+	actual := []string{
+		emptySearch().AddDocvalueField("tag", "").AddDocvalueField("date", "epoch_millis").String(),
+		emptySearch().AddField("user.*", "").AddField("date", "yyyy-MM-dd").String(),
+		emptySearch().StoredFields(NoStoredFields).String(),
+		emptySearch().AddScriptField("price_with_tax", "doc['price'].value * params.rate", Dict{"rate": 1.2}).AddScriptField("year", "doc['date'].value.year", nil).String(),
+	}
+	expected := []string{
+		`{"docvalue_fields":["tag",{"field":"date","format":"epoch_millis"}]}`,
+		`{"fields":["user.*",{"field":"date","format":"yyyy-MM-dd"}]}`,
+		`{"stored_fields":["_none_"]}`,
+		`{"script_fields":{"price_with_tax":{"script":{"lang":"painless","params":{"rate":1.2},"source":"doc['price'].value * params.rate"}},"year":{"script":{"lang":"painless","source":"doc['date'].value.year"}}}}`,
+	}
+	equals(t, actual, expected)
+	// the values are returned in the fields of the hits
+	parser := &SearchResultParser{}
+	result, err := parser.Parse([]byte(`{"took":1,"hits":{"total":1,"hits":[{"_index":"products","_id":"1","_score":1.0,"fields":{"price_with_tax":[12.0],"date":["2016-01-01"]}}]}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fields := result.(SearchResult).Hits.Hits[0].Fields
+	if fields["price_with_tax"][0] != 12.0 || fields["date"][0] != "2016-01-01" {
+		t.Error("Unexpected fields", fields)
+	}
+}
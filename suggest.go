@@ -0,0 +1,189 @@
+package elastic
+
+import ()
+
+const (
+	// SUGGEST constant name of the suggest parameter of a Search API query
+	SUGGEST = "suggest"
+	// TermSuggester a suggester that proposes terms based on edit distance
+	TermSuggester = "term"
+	// PhraseSuggester a suggester that proposes whole corrected phrases
+	PhraseSuggester = "phrase"
+	// CompletionSuggester a suggester for auto-complete on a 'completion' field
+	CompletionSuggester = "completion"
+	// SuggestMode a parameter of term suggesters and direct generators. Possible values: missing (default), popular, always.
+	SuggestMode = "suggest_mode"
+	// DirectGenerators a parameter of phrase suggester defining how candidate terms are generated
+	DirectGenerators = "direct_generator"
+	// Collate a parameter of phrase suggester used to check suggestions against a query
+	Collate = "collate"
+	// Prune a parameter of phrase suggester collate, when set to true all suggestions are returned with a 'collate_match' flag
+	Prune = "prune"
+	// SkipDuplicates a parameter of completion suggester used to filter out suggestions with the same text
+	SkipDuplicates = "skip_duplicates"
+	// Contexts a parameter of completion suggester used to filter suggestions by category or geo contexts
+	Contexts = "contexts"
+)
+
+// Suggester a structure representing a named suggester of a Search API query (e.g. term, phrase, completion)
+type Suggester struct {
+	name   string
+	kind   string
+	text   string
+	prefix string
+	kv     Dict
+}
+
+// DirectGenerator a structure representing a candidate generator of a phrase suggester
+type DirectGenerator struct {
+	kv Dict
+}
+
+// newSuggester creates a new suggester of the given kind on the given field
+func newSuggester(name, kind, field string) *Suggester {
+	return &Suggester{name: name, kind: kind, kv: Dict{Field: field}}
+}
+
+// NewTermSuggester creates a 'term' suggester that proposes corrections of each term of the text
+func NewTermSuggester(name, field string) *Suggester {
+	return newSuggester(name, TermSuggester, field)
+}
+
+// NewPhraseSuggester creates a 'phrase' suggester that proposes corrections of the whole text (e.g. "did you mean")
+func NewPhraseSuggester(name, field string) *Suggester {
+	return newSuggester(name, PhraseSuggester, field)
+}
+
+// NewCompletionSuggester creates a 'completion' suggester on a field of type completion (e.g. auto-complete)
+func NewCompletionSuggester(name, field string) *Suggester {
+	return newSuggester(name, CompletionSuggester, field)
+}
+
+// Text sets the text to get suggestions for
+func (suggester *Suggester) Text(text string) *Suggester {
+	suggester.text = text
+	return suggester
+}
+
+// Prefix sets the prefix to complete, for completion suggesters
+func (suggester *Suggester) Prefix(prefix string) *Suggester {
+	suggester.prefix = prefix
+	return suggester
+}
+
+// Size sets the maximum number of suggestions returned
+func (suggester *Suggester) Size(size int) *Suggester {
+	suggester.kv[Size] = size
+	return suggester
+}
+
+// Analyzer sets the analyzer used on the suggest text
+func (suggester *Suggester) Analyzer(analyzer string) *Suggester {
+	suggester.kv[ANALYZER] = analyzer
+	return suggester
+}
+
+// SuggestMode sets which terms are suggested by a term suggester (i.e. missing, popular, always)
+func (suggester *Suggester) SuggestMode(mode string) *Suggester {
+	suggester.kv[SuggestMode] = mode
+	return suggester
+}
+
+// AddDirectGenerator adds a candidate generator to a phrase suggester
+func (suggester *Suggester) AddDirectGenerator(generator *DirectGenerator) *Suggester {
+	generators, _ := suggester.kv[DirectGenerators].([]Dict)
+	suggester.kv[DirectGenerators] = append(generators, generator.kv)
+	return suggester
+}
+
+// Collate checks each suggestion of a phrase suggester against the given query, in which {{suggestion}} is replaced by the suggestion.
+// With prune, suggestions without matches are returned with 'collate_match' set to false instead of being removed.
+func (suggester *Suggester) Collate(query Query, params Dict, prune bool) *Suggester {
+	collate := Dict{"query": Dict{"source": Dict{query.Name(): query.KV()}}}
+	if len(params) > 0 {
+		collate["params"] = params
+	}
+	if prune {
+		collate[Prune] = true
+	}
+	suggester.kv[Collate] = collate
+	return suggester
+}
+
+// Highlight sets the tags surrounding the changed tokens of phrase suggestions
+func (suggester *Suggester) Highlight(pre, post string) *Suggester {
+	suggester.kv[HIGHLIGHT] = Dict{"pre_tag": pre, "post_tag": post}
+	return suggester
+}
+
+// Fuzzy enables fuzzy matching of a completion suggester with the given fuzziness (e.g. AUTO, 1, 2)
+func (suggester *Suggester) Fuzzy(fuzziness interface{}) *Suggester {
+	suggester.kv["fuzzy"] = Dict{Fuzziness: fuzziness}
+	return suggester
+}
+
+// Context filters the suggestions of a completion suggester by the values of a context (e.g. a category)
+func (suggester *Suggester) Context(name string, values ...interface{}) *Suggester {
+	if suggester.kv[Contexts] == nil {
+		suggester.kv[Contexts] = make(Dict)
+	}
+	suggester.kv[Contexts].(Dict)[name] = values
+	return suggester
+}
+
+// SkipDuplicates filters out suggestions of a completion suggester with the same text
+func (suggester *Suggester) SkipDuplicates(skip bool) *Suggester {
+	suggester.kv[SkipDuplicates] = skip
+	return suggester
+}
+
+// Add adds a parameter to this suggester
+func (suggester *Suggester) Add(name string, value interface{}) *Suggester {
+	suggester.kv[name] = value
+	return suggester
+}
+
+// Dict returns a dictionary representation of this suggester
+func (suggester *Suggester) Dict() Dict {
+	dict := Dict{suggester.kind: suggester.kv}
+	if suggester.text != "" {
+		dict["text"] = suggester.text
+	}
+	if suggester.prefix != "" {
+		dict[Prefix] = suggester.prefix
+	}
+	return dict
+}
+
+// String returns a string representation of this suggester
+func (suggester *Suggester) String() string {
+	return String(Dict{suggester.name: suggester.Dict()})
+}
+
+// NewDirectGenerator creates a candidate generator on the given field
+func NewDirectGenerator(field string) *DirectGenerator {
+	return &DirectGenerator{kv: Dict{Field: field}}
+}
+
+// SuggestMode sets which terms are generated (i.e. missing, popular, always)
+func (generator *DirectGenerator) SuggestMode(mode string) *DirectGenerator {
+	generator.kv[SuggestMode] = mode
+	return generator
+}
+
+// Add adds a parameter to this generator (e.g. min_word_length, pre_filter, post_filter)
+func (generator *DirectGenerator) Add(name string, value interface{}) *DirectGenerator {
+	generator.kv[name] = value
+	return generator
+}
+
+// AddSuggester adds named suggesters to this search request
+func (search *Search) AddSuggester(suggesters ...*Suggester) *Search {
+	if search.query[SUGGEST] == nil {
+		search.query[SUGGEST] = make(Dict)
+	}
+	for _, suggester := range suggesters {
+		search.query[SUGGEST].(Dict)[suggester.name] = suggester.Dict()
+	}
+	return search
+}
@@ -0,0 +1,37 @@
+package elastic
+
+import (
+	"testing"
+)
+
+// test for suggesters
+func TestSuggester(t *testing.T) {
+	actual := []string{
+		NewTermSuggester("my-suggestion", "message").Text("tring out Elasticsearch").SuggestMode("popular").String(),
+		NewPhraseSuggester("simple_phrase", "title.trigram").Text("noble prize").Size(1).AddDirectGenerator(NewDirectGenerator("title.trigram").SuggestMode("always")).Collate(NewMatch().Add("{{field_name}}", "{{suggestion}}"), Dict{"field_name": "title"}, true).String(),
+		NewCompletionSuggester("song-suggest", "suggest").Prefix("nor").Fuzzy("AUTO").Context("genre", "rock", "jazz").SkipDuplicates(true).String(),
+		emptySearch().AddSuggester(NewTermSuggester("a", "body").Text("foo"), NewTermSuggester("b", "title").Text("bar")).String(),
+	}
+	expected := []string{
+		`{"my-suggestion":{"term":{"field":"message","suggest_mode":"popular"},"text":"tring out Elasticsearch"}}`,
+		`{"simple_phrase":{"phrase":{"collate":{"params":{"field_name":"title"},"prune":true,"query":{"source":{"match":{"{{field_name}}":"{{suggestion}}"}}}},"direct_generator":[{"field":"title.trigram","suggest_mode":"always"}],"field":"title.trigram","size":1},"text":"noble prize"}}`,
+		`{"song-suggest":{"completion":{"contexts":{"genre":["rock","jazz"]},"field":"suggest","fuzzy":{"fuzziness":"AUTO"},"skip_duplicates":true},"prefix":"nor"}}`,
+		`{"suggest":{"a":{"term":{"field":"body"},"text":"foo"},"b":{"term":{"field":"title"},"text":"bar"}}}`,
+	}
+	equals(t, actual, expected)
+}
+
+// test for parsing suggestions of a search result
+func TestSuggestResult(t *testing.T) {
+	parser := &SearchResultParser{}
+	input := []string{
+		`{"took":2,"timed_out":false,"_shards":{"total":1,"successful":1,"failed":0},"hits":{"total":0,"max_score":null,"hits":[]},"suggest":{"my-suggestion":[{"text":"tring","offset":0,"length":5,"options":[{"text":"string","score":0.8,"freq":1}]}],"song-suggest":[{"text":"nir","offset":0,"length":3,"options":[{"text":"Nirvana","_index":"music","_type":"_doc","_id":"1","_score":34.0,"_source":{"suggest":["Nevermind","Nirvana"]}}]}]}}`,
+	}
+	expected := []interface{}{
+		SearchResult{Took: 2, Shards: Shard{Total: 1, Successful: 1}, Hits: Hits{Hits: []SearchHits{}}, Suggest: map[string][]Suggestion{
+			"my-suggestion": []Suggestion{Suggestion{Text: "tring", Length: 5, Options: []SuggestionOption{SuggestionOption{Text: "string", Score: 0.8, Freq: 1}}}},
+			"song-suggest":  []Suggestion{Suggestion{Text: "nir", Length: 3, Options: []SuggestionOption{SuggestionOption{Text: "Nirvana", Index: "music", Type: "_doc", ID: "1", Score: 34, Source: Dict{"suggest": []interface{}{"Nevermind", "Nirvana"}}}}}},
+		}},
+	}
+	checkParsingResult(t, input, parser, expected)
+}
@@ -0,0 +1,176 @@
+package elastic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// TASKS constant name of the Tasks API request
+	TASKS = "tasks"
+	// CANCEL constant name of the task cancellation request
+	CANCEL = "cancel"
+	// Actions a url param of the Tasks API used to filter tasks by action (e.g. *reindex, *byquery)
+	Actions = "actions"
+	// Nodes a url param of the Tasks API used to filter tasks by the nodes running them
+	Nodes = "nodes"
+	// ParentTaskID a url param of the Tasks API used to filter tasks by their parent task
+	ParentTaskID = "parent_task_id"
+	// Detailed a url param of the Tasks API used to get more details about the status of tasks
+	Detailed = "detailed"
+	// Timeout a url param that defines how long to wait (e.g. 30s)
+	Timeout = "timeout"
+)
+
+// Tasks a request representing a Tasks API call, it manages long running operations (e.g. reindex, delete by query)
+type Tasks struct {
+	client *Elasticsearch
+	parser *TasksResultParser
+	url    string
+	params map[string]string
+}
+
+// Tasks creates a Tasks API request
+func (client *Elasticsearch) Tasks() *Tasks {
+	url := fmt.Sprintf("http://%s/_%s", client.Addr, TASKS)
+	return newTasks(client, url)
+}
+
+// newTasks creates a new Tasks API call
+func newTasks(client *Elasticsearch, url string) *Tasks {
+	return &Tasks{
+		client: client,
+		parser: &TasksResultParser{},
+		url:    url,
+		params: make(map[string]string),
+	}
+}
+
+// Actions filters the tasks by action names, wildcards are accepted (e.g. *reindex)
+func (tasks *Tasks) Actions(actions ...string) *Tasks {
+	tasks.params[Actions] = strings.Join(actions, ",")
+	return tasks
+}
+
+// Nodes filters the tasks by the nodes running them
+func (tasks *Tasks) Nodes(nodes ...string) *Tasks {
+	tasks.params[Nodes] = strings.Join(nodes, ",")
+	return tasks
+}
+
+// Parent filters the tasks by their parent task
+func (tasks *Tasks) Parent(id string) *Tasks {
+	tasks.params[ParentTaskID] = id
+	return tasks
+}
+
+// Detailed requests the detailed status of the tasks
+func (tasks *Tasks) Detailed() *Tasks {
+	tasks.params[Detailed] = "true"
+	return tasks
+}
+
+// WaitForCompletion blocks a Get call until the task completes or the timeout (e.g. 30s) expires, an empty timeout uses the Elasticsearch default
+func (tasks *Tasks) WaitForCompletion(timeout string) *Tasks {
+	tasks.params[WaitForCompletion] = "true"
+	if timeout != "" {
+		tasks.params[Timeout] = timeout
+	}
+	return tasks
+}
+
+// AddParam adds a url parameter/value, e.g. group_by
+func (tasks *Tasks) AddParam(name, value string) *Tasks {
+	tasks.params[name] = value
+	return tasks
+}
+
+// urlString constructs the url of this Tasks API call, on the given path
+func (tasks *Tasks) urlString(path string) string {
+	url := tasks.url
+	if path != "" {
+		url += "/" + path
+	}
+	return urlString(url, tasks.params)
+}
+
+// List returns the tasks currently running
+// GET /_tasks
+func (tasks *Tasks) List() (*TaskListResult, error) {
+	result, err := tasks.client.Execute("GET", tasks.urlString(""), "", tasks.parser)
+	return taskListResult(result, err)
+}
+
+// Get returns the status of the task with the given identifier (e.g. oTUltX4IQMOUUVeiohTt8A:12345)
+// GET /_tasks/:id
+func (tasks *Tasks) Get(id string) (*TaskResult, error) {
+	return tasks.get(context.Background(), id)
+}
+
+// get returns the status of the task with the given identifier, the request is aborted when the given context is done
+func (tasks *Tasks) get(ctx context.Context, id string) (*TaskResult, error) {
+	result, err := tasks.client.execute(ctx, "GET", tasks.urlString(id), "", tasks.parser)
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case TaskResult:
+		return &res, nil
+	case Failure:
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
+}
+
+// Cancel cancels the task with the given identifier, or all the tasks matching the filters when the identifier is empty
+// POST /_tasks/:id/_cancel
+func (tasks *Tasks) Cancel(id string) (*TaskListResult, error) {
+	path := "_" + CANCEL
+	if id != "" {
+		path = id + "/" + path
+	}
+	result, err := tasks.client.Execute("POST", tasks.urlString(path), "", tasks.parser)
+	return taskListResult(result, err)
+}
+
+// taskListResult converts a parsed response into a task list
+func taskListResult(result interface{}, err error) (*TaskListResult, error) {
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case TaskListResult:
+		return &res, nil
+	case Failure:
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
+}
+
+// WaitForTask polls the status of the given task until it completes, it returns the final status and response of the task.
+// An error is returned if the context is done before completion (the pending poll request is aborted) or if the task failed.
+func (client *Elasticsearch) WaitForTask(ctx context.Context, id string, pollInterval time.Duration) (*TaskResult, error) {
+	for {
+		result, err := client.Tasks().get(ctx, id)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return nil, err
+		}
+		if result.Completed {
+			if result.Error != nil {
+				return result, fmt.Errorf("task %s failed: %s: %s", id, result.Error.Type, result.Error.Reason)
+			}
+			return result, nil
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(pollInterval):
+		}
+	}
+}
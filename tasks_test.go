@@ -0,0 +1,87 @@
+package elastic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// test for tasks url
+func TestTasksUrl(t *testing.T) {
+	actual := []string{
+		newTasks(nil, "/_tasks").Actions("*reindex", "*byquery").urlString(""),
+		newTasks(nil, "/_tasks").Parent("node1:42").urlString(""),
+		newTasks(nil, "/_tasks").urlString("node1:42/_cancel"),
+		newTasks(nil, "/_tasks").WaitForCompletion("").urlString("node1:42"),
+	}
+	expected := []string{
+		"/_tasks?actions=*reindex,*byquery",
+		"/_tasks?parent_task_id=node1:42",
+		"/_tasks/node1:42/_cancel",
+		"/_tasks/node1:42?wait_for_completion=true",
+	}
+	equals(t, actual, expected)
+}
+
+// test for tasks result parser
+func TestTasksResultParser(t *testing.T) {
+	parser := &TasksResultParser{}
+	input := []string{
+		`{"nodes":{"node1":{"name":"H5dfFeA","host":"127.0.0.1","tasks":{"node1:42":{"node":"node1","id":42,"type":"transport","action":"indices:data/write/reindex","status":{"total":120,"created":20,"batches":1},"cancellable":true}}}}}`,
+		`{"completed":false,"task":{"node":"node1","id":42,"type":"transport","action":"indices:data/write/reindex","status":{"total":120,"created":20,"batches":1},"cancellable":true}}`,
+		`{"error":{"root_cause":[{"type":"resource_not_found_exception","reason":"task [node1:43] isn't running"}],"type":"resource_not_found_exception","reason":"task [node1:43] isn't running"},"status":404}`,
+	}
+	task := TaskInfo{Node: "node1", ID: 42, Type: "transport", Action: "indices:data/write/reindex", Status: TaskStatus{Total: 120, Created: 20, Batches: 1}, Cancellable: true}
+	expected := []interface{}{
+		TaskListResult{Nodes: map[string]TaskNode{"node1": TaskNode{Name: "H5dfFeA", Host: "127.0.0.1", Tasks: map[string]TaskInfo{"node1:42": task}}}},
+		TaskResult{Completed: false, Task: task},
+		Failure{Err: Error{RootCause: []Dict{Dict{"type": "resource_not_found_exception", "reason": "task [node1:43] isn't running"}}, Type: "resource_not_found_exception", Reason: "task [node1:43] isn't running"}, Status: 404},
+	}
+	checkParsingResult(t, input, parser, expected)
+}
+
+// test for waiting for the completion of a task
+func TestWaitForTask(t *testing.T) {
+	polls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/_tasks/node1:99" {
+			// a poll request that only ends when the client aborts it
+			<-r.Context().Done()
+			return
+		}
+		polls++
+		if polls < 3 {
+			w.Write([]byte(`{"completed":false,"task":{"node":"node1","id":42,"status":{"total":2,"created":1}}}`))
+			return
+		}
+		w.Write([]byte(`{"completed":true,"task":{"node":"node1","id":42,"status":{"total":2,"created":2}},"response":{"took":3,"total":2,"created":2,"batches":1}}`))
+	}))
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+
+	result, err := client.WaitForTask(context.Background(), "node1:42", time.Millisecond)
+	if err != nil || polls != 3 {
+		t.Fatalf("Should complete after 3 polls: %d %v", polls, err)
+	}
+	if result.Task.Status.Created != 2 || result.Response == nil || result.Response.Created != 2 {
+		t.Errorf("Unexpected task result %v", result)
+	}
+
+	// a cancelled context stops polling
+	polls = 0
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.WaitForTask(ctx, "node1:42", time.Millisecond); err != context.Canceled {
+		t.Errorf("Should be cancelled: %v", err)
+	}
+	// a pending poll request is aborted when the context is done
+	ctx, cancel = context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := client.WaitForTask(ctx, "node1:99", time.Millisecond); err != context.DeadlineExceeded || time.Since(start) > time.Second {
+		t.Errorf("Should abort the pending request: %v %v", err, time.Since(start))
+	}
+}
@@ -0,0 +1,158 @@
+package elastic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Search template API
+const (
+	// SCRIPTS constant name of the API storing scripts and search templates
+	SCRIPTS = "_scripts"
+	// TEMPLATE constant name of the Search Template API
+	TEMPLATE = "template"
+	// RENDER constant name of the Render Template API
+	RENDER = "_render"
+	// Mustache the language of search templates
+	Mustache = "mustache"
+	// PARAMS constant name of the parameters of a search template
+	PARAMS = "params"
+)
+
+// PutSearchTemplate stores a search template under the given identifier.
+// The source is a mustache template, given as a string or as an object (e.g. Dict).
+// PUT /_scripts/:id
+func (client *Elasticsearch) PutSearchTemplate(id string, source interface{}) error {
+	url := fmt.Sprintf("http://%s/%s/%s", client.Addr, SCRIPTS, id)
+	body := String(Dict{"script": Dict{"lang": Mustache, SOURCE: source}})
+	return checkResult(client.Execute("PUT", url, body, &IndexResultParser{}))
+}
+
+// DeleteSearchTemplate deletes the stored search template with the given identifier
+// DELETE /_scripts/:id
+func (client *Elasticsearch) DeleteSearchTemplate(id string) error {
+	url := fmt.Sprintf("http://%s/%s/%s", client.Addr, SCRIPTS, id)
+	return checkResult(client.Execute("DELETE", url, "", &IndexResultParser{}))
+}
+
+// SearchTemplate a request of the Search Template API, i.e. a search whose body is a stored or inline template filled with parameters
+type SearchTemplate struct {
+	client *Elasticsearch
+	parser *SearchResultParser
+	url    string
+	params map[string]string
+	kv     Dict
+}
+
+// SearchTemplate creates a new Search Template API request on the given index (all indexes if empty)
+func (client *Elasticsearch) SearchTemplate(index string) *SearchTemplate {
+	url := client.request(index, "", -1, SEARCH+"/"+TEMPLATE)
+	return newSearchTemplate(client, url)
+}
+
+// newSearchTemplate creates a new Search Template API request
+func newSearchTemplate(client *Elasticsearch, url string) *SearchTemplate {
+	return &SearchTemplate{
+		client: client,
+		parser: &SearchResultParser{},
+		url:    url,
+		params: make(map[string]string),
+		kv:     make(Dict),
+	}
+}
+
+// ID sets the identifier of the stored template to use
+func (t *SearchTemplate) ID(id string) *SearchTemplate {
+	delete(t.kv, SOURCE)
+	t.kv["id"] = id
+	return t
+}
+
+// Source sets the inline template to use, given as a string or as an object (e.g. Dict)
+func (t *SearchTemplate) Source(source interface{}) *SearchTemplate {
+	delete(t.kv, "id")
+	t.kv[SOURCE] = source
+	return t
+}
+
+// Params sets the parameters filling the template
+func (t *SearchTemplate) Params(params Dict) *SearchTemplate {
+	t.kv[PARAMS] = params
+	return t
+}
+
+// Explain returns an explanation of the score of each hit
+func (t *SearchTemplate) Explain(explain bool) *SearchTemplate {
+	t.kv[EXPLAIN] = explain
+	return t
+}
+
+// AddParam adds a url parameter/value, e.g. routing
+func (t *SearchTemplate) AddParam(name, value string) *SearchTemplate {
+	t.params[name] = value
+	return t
+}
+
+// Dict returns the body of this request as a dictionary
+func (t *SearchTemplate) Dict() Dict {
+	return t.kv
+}
+
+// String returns a string representation of the body of this request
+func (t *SearchTemplate) String() string {
+	return String(t.kv)
+}
+
+// urlString constructs the url of this request
+func (t *SearchTemplate) urlString() string {
+	return urlString(t.url, t.params)
+}
+
+// Do submits this request and returns the search result
+// GET /:index/_search/template
+func (t *SearchTemplate) Do() (*SearchResult, error) {
+	result, err := t.client.Execute("GET", t.urlString(), t.String(), t.parser)
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case SearchResult:
+		return &res, nil
+	case Failure:
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
+}
+
+// Render renders the template with the Render Template API without running the search, to preview the query
+// GET /_render/template
+func (t *SearchTemplate) Render() (json.RawMessage, error) {
+	url := fmt.Sprintf("http://%s/%s/%s", t.client.Addr, RENDER, TEMPLATE)
+	result, err := t.client.Execute("GET", url, t.String(), &RenderTemplateResultParser{})
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case RenderTemplateResult:
+		return res.TemplateOutput, nil
+	case Failure:
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
+}
+
+// RenderLocal renders an inline template locally, without calling Elasticsearch (e.g. to unit test a template)
+func (t *SearchTemplate) RenderLocal() (string, error) {
+	var source string
+	switch s := t.kv[SOURCE].(type) {
+	case string:
+		source = s
+	case nil:
+		return "", errors.New("only inline templates can be rendered locally")
+	default:
+		source = String(s)
+	}
+	params, _ := t.kv[PARAMS].(Dict)
+	return RenderTemplate(source, params)
+}
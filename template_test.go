@@ -0,0 +1,99 @@
+package elastic
+
+import (
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// searchTemplate a template searching products by title, with optional filters
+const searchTemplate = `{"query":{"bool":{"must":{"match":{"title":"{{text}}"}}{{#filter}},"filter":{"terms":{"tag":{{#toJson}}tags{{/toJson}}}}{{/filter}}}},"size":{{size}}{{^size}}10{{/size}}}`
+
+// test for rendering search templates locally
+func TestRenderTemplate(t *testing.T) {
+	actual := []string{}
+	for _, params := range []Dict{
+		{"text": `the "quick" fox`, "filter": true, "tags": []string{"a", "b"}, "size": 5},
+		{"text": "fox"},
+	} {
+		rendered, err := newSearchTemplate(nil, "").Source(searchTemplate).Params(params).RenderLocal()
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, rendered)
+	}
+	// compare with the golden file
+	golden, err := ioutil.ReadFile("testdata/search_template.golden")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := strings.Split(strings.TrimSpace(string(golden)), "\n")
+	equals(t, actual, expected)
+}
+
+// test for mustache features
+func TestMustache(t *testing.T) {
+	params := Dict{"name": "<b>&", "list": []interface{}{"x", "y"}, "obj": Dict{"a": Dict{"b": 1.5}}, "empty": []string{}, "flag": true}
+	templates := []string{
+		`{{name}} {{{name}}} {{&name}}`,
+		`{{#list}}[{{.}}]{{/list}}`,
+		`{{obj.a.b}} {{list.1}} {{missing}}`,
+		`{{#obj}}{{a.b}}{{/obj}}{{^empty}}none{{/empty}}{{#flag}} on{{/flag}}`,
+		`{{#join}}list{{/join}} {{#join delimiter='||'}}list{{/join delimiter='||'}}`,
+		`{{! a comment }}{{#toJson}}obj{{/toJson}}`,
+	}
+	expected := []string{
+		`<b>& <b>& <b>&`,
+		`[x][y]`,
+		`1.5 y `,
+		`1.5none on`,
+		`x,y x||y`,
+		`{"a":{"b":1.5}}`,
+	}
+	actual := []string{}
+	for _, template := range templates {
+		rendered, err := RenderTemplate(template, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		actual = append(actual, rendered)
+	}
+	equals(t, actual, expected)
+	// invalid templates
+	for _, template := range []string{`{{#a}}`, `{{a`, `{{#a}}{{/b}}`, `{{}}`, `{{#}}abc`, `{{^}}x`, `x{{/}}`, `x{{/a}}`} {
+		if _, err := RenderTemplate(template, params); err == nil {
+			t.Error("Should fail to render", template)
+		}
+	}
+}
+
+// test for the Search Template API
+func TestSearchTemplate(t *testing.T) {
+	requests := []string{}
+	server := newTestServer(map[string]string{
+		"PUT /_scripts/products":         `{"acknowledged":true}`,
+		"GET /products/_search/template": `{"took":2,"hits":{"total":0,"hits":[]}}`,
+		"GET /_render/template":          `{"template_output":{"query":{"match":{"title":"fox"}}}}`,
+	}, &requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	if err := client.PutSearchTemplate("products", searchTemplate); err != nil {
+		t.Error(err)
+	}
+	template := client.SearchTemplate("products").ID("products").Params(Dict{"text": "fox"})
+	equals(t, []string{template.String()}, []string{`{"id":"products","params":{"text":"fox"}}`})
+	result, err := template.Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Took != 2 {
+		t.Error("Unexpected result", result)
+	}
+	output, err := client.SearchTemplate("").Source(`{"query":{"match":{"title":"{{text}}"}}}`).Params(Dict{"text": "fox"}).Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	equals(t, []string{string(output)}, []string{`{"query":{"match":{"title":"fox"}}}`})
+	expected := []string{"PUT /_scripts/products", "GET /products/_search/template", "GET /_render/template"}
+	equals(t, requests, expected)
+}
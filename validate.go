@@ -0,0 +1,158 @@
+package elastic
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// RewriteParam a parameter of Validate API returning the Lucene query the query is rewritten into
+	RewriteParam = "rewrite"
+	// AllShards a parameter of Validate API executing the validation on all shards instead of a random one per index
+	AllShards = "all_shards"
+)
+
+// ValidateQuery a request of the Validate API, it checks whether a query is valid without executing it
+type ValidateQuery struct {
+	client *Elasticsearch
+	parser *ValidateResultParser
+	url    string
+	params map[string]string
+	query  Dict
+}
+
+// Validate creates a Validation request
+func (client *Elasticsearch) Validate(index, class string, explain bool) *ValidateQuery {
+	url := client.request(index, class, -1, VALIDATE) + "/query"
+	validate := newValidateQuery(client, url)
+	if explain {
+		validate.AddParam(EXPLAIN, "")
+	}
+	return validate
+}
+
+// newValidateQuery creates a new Validate API request
+func newValidateQuery(client *Elasticsearch, url string) *ValidateQuery {
+	return &ValidateQuery{
+		client: client,
+		parser: &ValidateResultParser{},
+		url:    url,
+		params: make(map[string]string),
+		query:  make(Dict),
+	}
+}
+
+// AddParam adds a url parameter/value, e.g. q
+func (validate *ValidateQuery) AddParam(name, value string) *ValidateQuery {
+	validate.params[name] = value
+	return validate
+}
+
+// Pretty pretiffies the response result
+func (validate *ValidateQuery) Pretty() *ValidateQuery {
+	validate.AddParam("pretty", "")
+	return validate
+}
+
+// Rewrite returns the Lucene query the query is rewritten into in the explanations (e.g. to see the terms of a fuzzy query)
+func (validate *ValidateQuery) Rewrite(rewrite bool) *ValidateQuery {
+	validate.AddParam(RewriteParam, fmt.Sprint(rewrite))
+	return validate
+}
+
+// AllShards validates the query on all the shards instead of a random shard per index, it's used with Rewrite
+func (validate *ValidateQuery) AllShards(all bool) *ValidateQuery {
+	validate.AddParam(AllShards, fmt.Sprint(all))
+	return validate
+}
+
+// AddQuery adds a query to this request
+func (validate *ValidateQuery) AddQuery(query Query) *ValidateQuery {
+	validate.query[query.Name()] = query.KV()
+	return validate
+}
+
+// Add adds a query argument/value
+func (validate *ValidateQuery) Add(argument string, value interface{}) *ValidateQuery {
+	validate.query[argument] = value
+	return validate
+}
+
+// String returns a string representation of the body of this request
+func (validate *ValidateQuery) String() string {
+	return String(validate.query)
+}
+
+// urlString constructs the url of this request
+func (validate *ValidateQuery) urlString() string {
+	return urlString(validate.url, validate.params)
+}
+
+// Get submits this request and logs the result
+// GET /:index/:type/_validate/query
+func (validate *ValidateQuery) Get() {
+	validate.client.Execute("GET", validate.urlString(), validate.String(), validate.parser)
+}
+
+// Do submits this request and returns the validation result, an invalid query is not an error but a result with Valid false
+// GET /:index/:type/_validate/query
+func (validate *ValidateQuery) Do() (*ExplainResult, error) {
+	result, err := validate.client.Execute("GET", validate.urlString(), validate.String(), validate.parser)
+	if err != nil {
+		return nil, err
+	}
+	switch res := result.(type) {
+	case ExplainResult:
+		return &res, nil
+	case Failure:
+		return nil, res
+	}
+	return nil, errors.New("Failed to parse response")
+}
+
+// Errors returns the reasons why the query is not valid
+func (result *ExplainResult) Errors() []string {
+	errs := []string{}
+	if result.Error != "" {
+		errs = append(errs, result.Error)
+	}
+	for _, explanation := range result.Explanations {
+		if !explanation.Valid && explanation.Error != "" {
+			errs = append(errs, fmt.Sprintf("%s: %s", explanation.Index, explanation.Error))
+		}
+	}
+	return errs
+}
+
+// ValidateSearches validates the query of each of the given search bodies (e.g. saved searches, by name) against the given index.
+// It returns the errors of the searches that are not valid by name, so that all the saved searches can be checked at once (e.g. in CI).
+func (client *Elasticsearch) ValidateSearches(index string, searches map[string][]byte) map[string]error {
+	errs := make(map[string]error)
+	names := []string{}
+	for name := range searches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		search, err := ParseSearchBody(searches[name])
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		validate := client.Validate(index, "", true)
+		if query, ok := search.query["query"]; ok {
+			validate.Add("query", query)
+		}
+		result, err := validate.Do()
+		if err != nil {
+			errs[name] = err
+			continue
+		}
+		if !result.Valid {
+			errs[name] = fmt.Errorf("invalid query: %s", strings.Join(result.Errors(), "; "))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,75 @@
+package elastic
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newValidateServer a stand-in of a test cluster that rejects the queries with unknown names
+func newValidateServer(requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*requests = append(*requests, r.Method+" "+r.URL.Path+"?"+r.URL.RawQuery)
+		search, err := ParseSearchBody(body)
+		if err != nil {
+			w.Write([]byte(`{"valid":false,"error":"org.elasticsearch.common.ParsingException: failed to parse"}`))
+			return
+		}
+		for _, issue := range Lint(search) {
+			if issue.Severity == SeverityError {
+				w.Write([]byte(`{"valid":false,"_shards":{"total":1,"successful":1,"failed":0},"explanations":[{"index":"books","valid":false,"error":"` + issue.Message + `"}]}`))
+				return
+			}
+		}
+		w.Write([]byte(`{"valid":true,"_shards":{"total":1,"successful":1,"failed":0},"explanations":[{"index":"books","shard":0,"valid":true,"explanation":"title:fox"}]}`))
+	}))
+}
+
+// test for the Validate API
+func TestValidate(t *testing.T) {
+	requests := []string{}
+	server := newValidateServer(&requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	// a valid query
+	validate := client.Validate("books", "", false).Rewrite(true).AllShards(true).AddQuery(NewQuery("query").SetQuery(MatchQuery("title", "fox")))
+	result, err := validate.Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !result.Valid || result.Explanations[0].Explanation != "title:fox" || *result.Explanations[0].Shard != 0 {
+		t.Error("Should be valid", result)
+	}
+	// an invalid query is a result, not an error
+	result, err = client.Validate("books", "", true).Add("query", Dict{"rage": Dict{}}).Do()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Valid {
+		t.Error("Should not be valid", result)
+	}
+	equals(t, result.Errors(), []string{"books: unknown query 'rage'"})
+	if !strings.Contains(requests[0], "rewrite=true") || !strings.Contains(requests[0], "all_shards=true") || requests[1] != "GET /books/_validate/query?explain" {
+		t.Error("Unexpected requests", requests)
+	}
+}
+
+// test for the validation of saved searches
+func TestValidateSearches(t *testing.T) {
+	requests := []string{}
+	server := newValidateServer(&requests)
+	defer server.Close()
+	client := &Elasticsearch{Addr: strings.TrimPrefix(server.URL, "http://")}
+	errs := client.ValidateSearches("books", map[string][]byte{
+		"by_title":  []byte(`{"query":{"match":{"title":"fox"}},"size":10}`),
+		"by_price":  []byte(`{"query":{"rage":{"price":{"gte":10}}}}`),
+		"malformed": []byte(`{"query":`),
+	})
+	if len(errs) != 2 || errs["by_price"] == nil || errs["malformed"] == nil {
+		t.Error("Unexpected errors", errs)
+	}
+	equals(t, []string{errs["by_price"].Error()}, []string{"invalid query: books: unknown query 'rage'"})
+}